@@ -2,8 +2,23 @@ package ast
 
 import "fmt"
 
+// NodeError is an error associated with a node in a template's AST
+type NodeError struct {
+	Node Node
+	Err  error
+}
+
+func (ne *NodeError) Error() string {
+	return ne.Node.Pos().String() + ": " + ne.Err.Error()
+}
+
+func (ne *NodeError) Unwrap() error {
+	return ne.Err
+}
+
+// PosError returns an error with the position of n prepended
 func PosError(n Node, format string, v ...any) error {
-	return fmt.Errorf(n.Pos().String()+": "+format, v...)
+	return &NodeError{Node: n, Err: fmt.Errorf(format, v...)}
 }
 
 type Node interface {
@@ -0,0 +1,74 @@
+package salix
+
+import (
+	"errors"
+
+	"go.elara.ws/salix/ast"
+)
+
+var (
+	// ErrBreak is returned by TagContext.Execute when a #break tag is executed.
+	// Tags that implement loops should stop iterating when they receive it.
+	ErrBreak = errors.New("break outside of loop")
+	// ErrContinue is returned by TagContext.Execute when a #continue tag is executed.
+	// Tags that implement loops should skip to the next iteration when they receive it.
+	ErrContinue = errors.New("continue outside of loop")
+)
+
+// breakTag represents a #break tag within a Salix template
+type breakTag struct{}
+
+func (bt breakTag) Run(tc *TagContext, block, args []ast.Node) error {
+	return loopControl(tc, args, ErrBreak)
+}
+
+// continueTag represents a #continue tag within a Salix template
+type continueTag struct{}
+
+func (ct continueTag) Run(tc *TagContext, block, args []ast.Node) error {
+	return loopControl(tc, args, ErrContinue)
+}
+
+// loopControl returns signal if the optional condition in args is true.
+// The interpreter passes signals through without adding any information to
+// them, so that they reach the innermost loop as cheaply as possible.
+func loopControl(tc *TagContext, args []ast.Node, signal error) error {
+	if len(args) > 1 {
+		return tc.PosError(tc.Tag, "expected at most one argument, got %d", len(args))
+	} else if len(args) == 1 {
+		val, err := tc.GetValue(args[0], nil)
+		if err != nil {
+			return err
+		}
+
+		cond, ok := tc.IsTrue(val)
+		if !ok {
+			return tc.PosError(args[0], "expected boolean argument, got %T", val)
+		} else if !cond {
+			return nil
+		}
+	}
+
+	return tc.PosError(tc.Tag, "%w", signal)
+}
+
+// isLoopSignal returns true if err is a break or continue signal
+func isLoopSignal(err error) bool {
+	return errors.Is(err, ErrBreak) || errors.Is(err, ErrContinue)
+}
+
+// handleLoopSignal handles the error returned by an iteration of a loop.
+// If err is a break signal, stop is true. Continue signals are discarded,
+// and any other errors are returned as-is.
+func handleLoopSignal(err error) (stop bool, _ error) {
+	switch {
+	case err == nil:
+		return false, nil
+	case errors.Is(err, ErrBreak):
+		return true, nil
+	case errors.Is(err, ErrContinue):
+		return false, nil
+	default:
+		return true, err
+	}
+}
@@ -0,0 +1,36 @@
+package salix
+
+import "go.elara.ws/salix/ast"
+
+// captureTag represents a #capture tag within a Salix template
+type captureTag struct{}
+
+func (ct captureTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if len(args) != 1 {
+		return tc.PosError(tc.Tag, "expected one argument, got %d", len(args))
+	}
+
+	val, err := tc.GetValue(args[0], nil)
+	if err != nil {
+		return err
+	}
+
+	name, ok := val.(string)
+	if !ok {
+		return tc.PosError(args[0], "invalid argument type: %T (expected string)", val)
+	}
+
+	if err := tc.t.checkShadow(args[0], name); err != nil {
+		return err
+	}
+
+	// Any values in the block have already been escaped when they were
+	// written, so the output is stored as HTML to avoid escaping it twice.
+	out, err := tc.ExecuteToMemory(block, nil)
+	if err != nil {
+		return err
+	}
+	tc.local.define(name, HTML(out))
+
+	return nil
+}
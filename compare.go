@@ -0,0 +1,138 @@
+package salix
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.elara.ws/salix/ast"
+)
+
+// Comparer can be implemented by types that should support Salix's ordering
+// operators (<, <=, >, and >=) and the sort function. Compare should return a
+// negative number if the receiver is less than other, zero if they're equal,
+// and a positive number if the receiver is greater than other.
+type Comparer interface {
+	Compare(other any) int
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	comparerType = reflect.TypeOf((*Comparer)(nil)).Elem()
+)
+
+// isOrderingOp returns true if op is one of the ordering operators
+func isOrderingOp(op string) bool {
+	return op == "<" || op == "<=" || op == ">" || op == ">="
+}
+
+// compareValues compares a and b, returning a negative number if a is less than b,
+// zero if they're equal, and a positive number if a is greater than b. It supports
+// types that implement Comparer, times, numbers, and strings.
+func compareValues(a, b reflect.Value) (int, error) {
+	// Unwrap interface values, such as the keys of a map[any]any,
+	// so that the underlying values are compared
+	if a.Kind() == reflect.Interface {
+		a = a.Elem()
+	}
+	if b.Kind() == reflect.Interface {
+		b = b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		return 0, fmt.Errorf("cannot compare nil values")
+	}
+
+	if a.Type().Implements(comparerType) {
+		return a.Interface().(Comparer).Compare(b.Interface()), nil
+	} else if b.Type().Implements(comparerType) {
+		return -b.Interface().(Comparer).Compare(a.Interface()), nil
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time)), nil
+	}
+
+	ca, cb := classifyNum(a), classifyNum(b)
+	switch {
+	case ca == floatNum || cb == floatNum:
+		if cb != notNumeric && ca != notNumeric {
+			x, y := toFloat64(a), toFloat64(b)
+			if math.IsNaN(x) || math.IsNaN(y) {
+				return 0, fmt.Errorf("cannot compare NaN values")
+			}
+			return cmp.Compare(x, y), nil
+		}
+	case ca != notNumeric && cb != notNumeric:
+		return compareInts(a, ca, b, cb), nil
+	}
+
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), nil
+	}
+
+	return 0, fmt.Errorf("cannot compare values of types %s and %s", a.Type(), b.Type())
+}
+
+// performTimeOp performs operations involving time.Time and time.Duration values.
+// If neither a nor b are times or durations, it returns false.
+func performTimeOp(op ast.Operator, a, b reflect.Value) (any, bool, error) {
+	aType, bType := a.Type(), b.Type()
+	switch {
+	case aType == timeType && bType == timeType:
+		x, y := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch op.Value {
+		case "==":
+			return x.Equal(y), true, nil
+		case "!=":
+			return !x.Equal(y), true, nil
+		case "-":
+			return x.Sub(y), true, nil
+		}
+		if isOrderingOp(op.Value) {
+			return compareResult(op.Value, x.Compare(y)), true, nil
+		}
+	case aType == timeType && bType == durationType:
+		x, d := a.Interface().(time.Time), b.Interface().(time.Duration)
+		switch op.Value {
+		case "+":
+			return x.Add(d), true, nil
+		case "-":
+			return x.Add(-d), true, nil
+		}
+	case aType == durationType && bType == timeType:
+		if op.Value == "+" {
+			return b.Interface().(time.Time).Add(a.Interface().(time.Duration)), true, nil
+		}
+	case aType == durationType || bType == durationType:
+		if classifyNum(a) == notNumeric || classifyNum(b) == notNumeric {
+			return nil, false, nil
+		}
+
+		// Adding or subtracting two durations returns a duration, and so does
+		// multiplying or dividing a duration by a number, but other operations,
+		// such as comparisons, are handled like regular numbers.
+		bothDurations := aType == bType
+		if (bothDurations && (op.Value == "+" || op.Value == "-")) ||
+			(!bothDurations && (op.Value == "*" || (op.Value == "/" && aType == durationType))) {
+			res, err := performNumericOp(op, a, b)
+			if err != nil {
+				return nil, true, err
+			}
+			switch res := res.(type) {
+			case int64:
+				return time.Duration(res), true, nil
+			case float64:
+				return time.Duration(res), true, nil
+			}
+		}
+		return nil, false, nil
+	default:
+		return nil, false, nil
+	}
+	return nil, true, ast.PosError(op, "invalid operator for times: %q", op.Value)
+}
@@ -0,0 +1,109 @@
+package salix
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type version struct {
+	major, minor int
+}
+
+func (v version) Compare(other any) int {
+	o := other.(version)
+	if v.major != o.major {
+		return v.major - o.major
+	}
+	return v.minor - o.minor
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+func TestOrderingOps(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	vars := map[string]any{
+		"now":    now,
+		"later":  now.Add(time.Hour),
+		"hour":   time.Hour,
+		"minute": time.Minute,
+		"v1":     version{1, 2},
+		"v2":     version{1, 10},
+	}
+
+	testCases := []struct {
+		expr     string
+		expected any
+	}{
+		{`"a" < "b"`, true},
+		{`"abc" >= "abd"`, false},
+		{`"b" > "B"`, true},
+		{`now < later`, true},
+		{`now >= later`, false},
+		{`now == later - hour`, true},
+		{`now + hour == later`, true},
+		{`hour + now == later`, true},
+		{`later - now`, time.Hour},
+		{`hour > minute`, true},
+		{`hour - minute`, 59 * time.Minute},
+		{`minute * 2`, 2 * time.Minute},
+		{`2 * minute`, 2 * time.Minute},
+		{`hour / 4`, 15 * time.Minute},
+		{`v1 < v2`, true},
+		{`v2 <= v1`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			val, err := evalStr(t, tc.expr, vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if val != tc.expected {
+				t.Errorf("Expected %v (%T), got %v (%T)", tc.expected, tc.expected, val, val)
+			}
+		})
+	}
+}
+
+func TestOrderingOpsInvalid(t *testing.T) {
+	vars := map[string]any{"now": time.Now()}
+	for _, expr := range []string{`now * now`, `now < 1`, `true < false`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := evalStr(t, expr, vars)
+			if err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	testCases := []struct {
+		in       any
+		expected []any
+	}{
+		{[]int{3, 1, 2}, []any{1, 2, 3}},
+		{[]any{2.5, 1, uint(2)}, []any{1, uint(2), 2.5}},
+		{[]string{"b", "c", "a"}, []any{"a", "b", "c"}},
+		{[]version{{2, 0}, {1, 10}, {1, 2}}, []any{version{1, 2}, version{1, 10}, version{2, 0}}},
+	}
+
+	for _, tc := range testCases {
+		out, err := tmplSort(tc.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Expected %v, got %v", tc.expected, out)
+		}
+	}
+
+	_, err := tmplSort([]any{1, "a"})
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -0,0 +1,91 @@
+package salix
+
+import (
+	"strings"
+
+	"go.elara.ws/salix/ast"
+)
+
+// Error represents an error that occurred while executing a template.
+// Errors returned by Template.Execute can be converted to *Error using
+// errors.As.
+type Error struct {
+	// Position is the position of the node that caused the error
+	Position ast.Position
+	// Source is a textual representation of the node that caused the error
+	Source string
+	// Err is the underlying cause of the error
+	Err error
+	// Stack contains the tags that were being executed when the error occurred,
+	// such as #include or #macro tags, as well as macros called as functions,
+	// starting with the innermost one.
+	Stack []Frame
+	// Locals contains the local variables that were in scope when the error occurred
+	Locals map[string]any
+}
+
+// Frame represents a tag or macro call that was being executed when an error occurred
+type Frame struct {
+	// Position is the position of the tag or macro call
+	Position ast.Position
+	// Source is a textual representation of the tag or macro call
+	Source string
+}
+
+func (e *Error) Error() string {
+	sb := strings.Builder{}
+	for i := len(e.Stack) - 1; i >= 0; i-- {
+		sb.WriteString(e.Stack[i].Position.String())
+		sb.WriteString(": ")
+		sb.WriteString(e.Stack[i].Source)
+		sb.WriteString(" ->\n")
+	}
+	sb.WriteString(e.Position.String())
+	sb.WriteString(": ")
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// toError converts err into an *Error. If err doesn't contain a position,
+// the position of node is used instead. If the error doesn't have any local
+// variables recorded yet, the variables visible from local are copied into it.
+func toError(node ast.Node, err error, local *scope) *Error {
+	var e *Error
+	switch err := err.(type) {
+	case *Error:
+		e = err
+	case *ast.NodeError:
+		e = &Error{
+			Position: err.Node.Pos(),
+			Source:   valueToString(err.Node),
+			Err:      err.Err,
+		}
+	default:
+		e = &Error{
+			Position: node.Pos(),
+			Source:   valueToString(node),
+			Err:      err,
+		}
+	}
+
+	if e.Locals == nil && local != nil {
+		e.Locals = local.flatten()
+	}
+
+	return e
+}
+
+// pushFrame converts err into an *Error and adds the given
+// tag or macro call to its stack.
+func pushFrame(node ast.Node, err error) *Error {
+	e := toError(node, err, nil)
+	e.Stack = append(e.Stack, Frame{
+		Position: node.Pos(),
+		Source:   valueToString(node),
+	})
+	return e
+}
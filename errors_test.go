@@ -0,0 +1,67 @@
+package salix
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestErrorStack(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("inner.html", "\n#(x.Y)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("outer.html", `#include("inner.html")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	var serr *Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("Expected *Error, got %T", err)
+	}
+
+	if serr.Position.Name != "inner.html" || serr.Position.Line != 2 {
+		t.Errorf("Unexpected position: %s", serr.Position)
+	}
+
+	if serr.Source != "x" {
+		t.Errorf("Expected source %q, got %q", "x", serr.Source)
+	}
+
+	if len(serr.Stack) != 1 {
+		t.Fatalf("Expected 1 frame, got %d", len(serr.Stack))
+	}
+
+	if serr.Stack[0].Position.Name != "outer.html" || serr.Stack[0].Source != `#include("inner.html")` {
+		t.Errorf("Unexpected frame: %+v", serr.Stack[0])
+	}
+
+	const expected = "outer.html: line 1, col 1: #include(\"inner.html\") ->\ninner.html: line 2, col 3: no such variable: x"
+	if serr.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, serr.Error())
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	errTest := errors.New("test error")
+	fn := func() (int, error) { return 0, errTest }
+
+	tmpl, err := New().ParseString("test", `#(fn())`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"fn": fn}).Execute(io.Discard)
+	if !errors.Is(err, errTest) {
+		t.Errorf("Expected error to wrap %q, got %q", errTest, err)
+	}
+}
@@ -0,0 +1,217 @@
+package salix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.elara.ws/salix/ast"
+)
+
+// excerptContext is the amount of lines shown before and after
+// the failing line in an error page's source excerpt.
+const excerptContext = 5
+
+// ErrorPage returns an http.Handler that responds with an HTML page describing err.
+// The page contains an excerpt of the failing template's source code, the chain of
+// tags that led to the error, and the local variables that were in scope when it
+// occurred, so it should only be used during development. If the page can't be
+// written, the error is logged.
+func (n *Namespace) ErrorPage(err error) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set("Content-Type", "text/html; charset=utf-8")
+		res.WriteHeader(http.StatusInternalServerError)
+		if werr := n.WriteErrorPage(res, err); werr != nil {
+			log.Println("salix: error page:", werr)
+		}
+	})
+}
+
+// WriteErrorPage writes an HTML page describing err to w.
+// See ErrorPage for more information.
+func (n *Namespace) WriteErrorPage(w io.Writer, err error) error {
+	page := errorPage{Message: err.Error()}
+
+	var serr *Error
+	if errors.As(err, &serr) {
+		page.Position = serr.Position.String()
+		page.Excerpt = n.sourceExcerpt(serr.Position)
+
+		page.Stack = append(page.Stack, errorFrame{serr.Position.String(), serr.Source})
+		for _, frame := range serr.Stack {
+			page.Stack = append(page.Stack, errorFrame{frame.Position.String(), frame.Source})
+		}
+
+		for name, val := range serr.Locals {
+			page.Locals = append(page.Locals, errorLocal{
+				Name:  name,
+				Type:  fmt.Sprintf("%T", val),
+				Value: fmt.Sprint(val),
+			})
+		}
+		sort.Slice(page.Locals, func(i, j int) bool {
+			return page.Locals[i].Name < page.Locals[j].Name
+		})
+	}
+
+	return errorPageTmpl.Execute(w, page)
+}
+
+type errorPage struct {
+	Message  string
+	Position string
+	Excerpt  []excerptLine
+	Stack    []errorFrame
+	Locals   []errorLocal
+}
+
+type excerptLine struct {
+	Number    int
+	Before    string
+	Highlight string
+	After     string
+	IsError   bool
+}
+
+type errorFrame struct {
+	Position string
+	Source   string
+}
+
+type errorLocal struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// sourceExcerpt returns the lines surrounding pos in the template it refers to,
+// with the failing span highlighted. If the template can't be found, it returns nil.
+func (n *Namespace) sourceExcerpt(pos ast.Position) []excerptLine {
+	tmpl, ok := n.GetTemplate(pos.Name)
+	if !ok || tmpl.src == nil {
+		return nil
+	}
+
+	lines := strings.Split(string(bytes.ReplaceAll(tmpl.src, []byte("\r\n"), []byte{'\n'})), "\n")
+	if pos.Line < 1 || pos.Line > len(lines) {
+		return nil
+	}
+
+	start := max(pos.Line-excerptContext, 1)
+	end := min(pos.Line+excerptContext, len(lines))
+
+	out := make([]excerptLine, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		line := excerptLine{Number: i, Before: lines[i-1]}
+		if i == pos.Line {
+			runes := []rune(lines[i-1])
+			col := min(max(pos.Col-1, 0), len(runes))
+			spanEnd := findSpanEnd(runes, col)
+			line.IsError = true
+			line.Before = string(runes[:col])
+			line.Highlight = string(runes[col:spanEnd])
+			line.After = string(runes[spanEnd:])
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// findSpanEnd finds the end of the tag or expression starting at index start in line.
+// It's a heuristic, since the AST doesn't record where nodes end.
+func findSpanEnd(line []rune, start int) int {
+	depth := 0
+	var quote rune
+	for i := start; i < len(line); i++ {
+		char := line[i]
+		if quote != 0 {
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch char {
+		case '"', '`':
+			quote = char
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case '#', '!', '?', '.', '_':
+		default:
+			if depth == 0 && !isAlphanumeric(char) {
+				return i
+			}
+		}
+	}
+	return len(line)
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+var errorPageTmpl = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html>
+	<head>
+		<meta charset="utf-8">
+		<title>Template Error</title>
+		<style>
+			body { font-family: sans-serif; margin: 2em; color: #222; }
+			h1 { color: #b00020; }
+			pre, code, td.mono { font-family: monospace; }
+			pre.message { background: #fdecea; padding: 1em; white-space: pre-wrap; }
+			table { border-collapse: collapse; margin-bottom: 2em; }
+			td, th { padding: 0.2em 0.8em; text-align: left; vertical-align: top; }
+			table.excerpt td.num { color: #888; text-align: right; user-select: none; }
+			table.excerpt tr.error { background: #fff3cd; }
+			table.excerpt mark { background: #f5c2c7; }
+			table.excerpt td.line { white-space: pre; }
+		</style>
+	</head>
+	<body>
+		<h1>Template Error</h1>
+		<pre class="message">{{.Message}}</pre>
+		{{if .Excerpt}}
+		<h2>{{.Position}}</h2>
+		<table class="excerpt">
+			{{range .Excerpt}}
+			<tr{{if .IsError}} class="error"{{end}}>
+				<td class="num mono">{{.Number}}</td>
+				<td class="line mono">{{.Before}}{{if .IsError}}<mark>{{.Highlight}}</mark>{{.After}}{{end}}</td>
+			</tr>
+			{{end}}
+		</table>
+		{{end}}
+		{{if .Stack}}
+		<h2>Stack</h2>
+		<table>
+			{{range .Stack}}
+			<tr><td class="mono">{{.Position}}</td><td><code>{{.Source}}</code></td></tr>
+			{{end}}
+		</table>
+		{{end}}
+		{{if .Locals}}
+		<h2>Local Variables</h2>
+		<table>
+			<tr><th>Name</th><th>Type</th><th>Value</th></tr>
+			{{range .Locals}}
+			<tr><td class="mono">{{.Name}}</td><td class="mono">{{.Type}}</td><td class="mono">{{.Value}}</td></tr>
+			{{end}}
+		</table>
+		{{end}}
+	</body>
+</html>
+`))
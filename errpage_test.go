@@ -0,0 +1,68 @@
+package salix
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorPage(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("inner.html", "<p>\n    #(user.Nmae)\n</p>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("outer.html", `#include("inner.html", user = "<admin>")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	rec := httptest.NewRecorder()
+	ns.ErrorPage(err).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+
+	body := rec.Body.String()
+	expected := []string{
+		`    #(<mark>user.Nmae</mark>)`,
+		`<code>#include(&#34;inner.html&#34;, ...)</code>`,
+		`<td class="mono">user</td><td class="mono">string</td><td class="mono">&lt;admin&gt;</td>`,
+	}
+	for _, substr := range expected {
+		if !strings.Contains(body, substr) {
+			t.Errorf("Expected error page to contain %q", substr)
+		}
+	}
+}
+
+func TestFindSpanEnd(t *testing.T) {
+	testCases := []struct {
+		line     string
+		start    int
+		expected string
+	}{
+		{`<p>#(x + 1)</p>`, 3, `#(x + 1)`},
+		{`#include("a(b.html")`, 0, `#include("a(b.html")`},
+		{`#(a.B[0] + c)`, 2, `a.B[0]`},
+		{`#if(x):`, 0, `#if(x)`},
+	}
+
+	for _, tc := range testCases {
+		line := []rune(tc.line)
+		end := findSpanEnd(line, tc.start)
+		if span := string(line[tc.start:end]); span != tc.expected {
+			t.Errorf("Expected %q, got %q", tc.expected, span)
+		}
+	}
+}
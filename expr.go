@@ -7,7 +7,10 @@ import (
 	"go.elara.ws/salix/ast"
 )
 
-func (t *Template) evalExpr(expr ast.Expr, local map[string]any) (any, error) {
+// evalExpr evaluates an expression from left to right. The logical operators
+// short-circuit, so their right operand is only evaluated if it's needed to
+// determine the result.
+func (t *Template) evalExpr(expr ast.Expr, local *scope) (any, error) {
 	val, err := t.getValue(expr.First, local)
 	if err != nil {
 		return nil, err
@@ -15,6 +18,15 @@ func (t *Template) evalExpr(expr ast.Expr, local map[string]any) (any, error) {
 	a := reflect.ValueOf(val)
 
 	for _, exprB := range expr.Rest {
+		if op := exprB.Operator; op.Value == "&&" || op.Value == "||" {
+			result, err := t.evalLogical(op, a, exprB.First, local)
+			if err != nil {
+				return nil, err
+			}
+			a = reflect.ValueOf(result)
+			continue
+		}
+
 		val, err := t.getValue(exprB.First, local)
 		if err != nil {
 			return nil, err
@@ -29,9 +41,94 @@ func (t *Template) evalExpr(expr ast.Expr, local map[string]any) (any, error) {
 		a = reflect.ValueOf(result)
 	}
 
+	if !a.IsValid() {
+		return nil, nil
+	}
 	return a.Interface(), nil
 }
 
+// evalLogical performs a logical operation on a and the value of the node b.
+// b is only evaluated if a doesn't determine the result on its own.
+func (t *Template) evalLogical(op ast.Operator, a reflect.Value, b ast.Node, local *scope) (bool, error) {
+	cond, ok := t.isTrue(a)
+	if !ok {
+		return false, ast.PosError(op, "logical operations may only be performed on boolean values")
+	}
+
+	if op.Value == "&&" && !cond {
+		return false, nil
+	} else if op.Value == "||" && cond {
+		return true, nil
+	}
+
+	val, err := t.getValue(b, local)
+	if err != nil {
+		return false, err
+	}
+
+	cond, ok = t.isTrue(reflect.ValueOf(val))
+	if !ok {
+		return false, ast.PosError(op, "logical operations may only be performed on boolean values")
+	}
+	return cond, nil
+}
+
+// Truthy can be implemented by types that define their own truth value
+// for use as conditions when truthiness mode is enabled.
+type Truthy interface {
+	Truthy() bool
+}
+
+// isTrue returns the truth value of v, as used by conditions such as the logical
+// operators, the ! operator, ternary expressions, and if tags. If v can't be used
+// as a condition, ok is false. Only booleans can be used as conditions unless
+// truthiness mode is enabled.
+func (t *Template) isTrue(v reflect.Value) (cond, ok bool) {
+	if v.Kind() == reflect.Bool {
+		return v.Bool(), true
+	} else if !t.ns.Truthiness {
+		return false, false
+	}
+	return isTruthy(v), true
+}
+
+// isTruthy returns false if v is an empty or zero value,
+// or if it implements Truthy and its Truthy method returns false.
+// Otherwise, it returns true.
+func isTruthy(v reflect.Value) bool {
+	if !v.IsValid() {
+		return false
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return false
+		}
+	}
+
+	if tv, ok := v.Interface().(Truthy); ok {
+		return tv.Truthy()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return v.Len() > 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() != 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() != 0
+	default:
+		return true
+	}
+}
+
 func (t *Template) performOp(a, b reflect.Value, op ast.Operator) (result any, err error) {
 	if op.Value == "in" {
 		a, b, err = handleIn(op, a, b)
@@ -40,6 +137,18 @@ func (t *Template) performOp(a, b reflect.Value, op ast.Operator) (result any, e
 		}
 	} else if !a.IsValid() || !b.IsValid() {
 		return handleNil(op, a, b)
+	} else if result, ok, err := performOverloadedOp(op, a, b); ok {
+		return result, err
+	} else if result, ok, err := performTimeOp(op, a, b); ok {
+		return result, err
+	} else if isOrderingOp(op.Value) && (a.Type().Implements(comparerType) || b.Type().Implements(comparerType)) {
+		c, err := compareValues(a, b)
+		if err != nil {
+			return nil, ast.PosError(op, "%w", err)
+		}
+		return compareResult(op.Value, c), nil
+	} else if classifyNum(a) != notNumeric && classifyNum(b) != notNumeric {
+		return performNumericOp(op, a, b)
 	} else if b.CanConvert(a.Type()) {
 		b = b.Convert(a.Type())
 	} else {
@@ -51,98 +160,13 @@ func (t *Template) performOp(a, b reflect.Value, op ast.Operator) (result any, e
 		return a.Equal(b), nil
 	case "!=":
 		return !a.Equal(b), nil
-	case "&&":
-		if a.Kind() != reflect.Bool || b.Kind() != reflect.Bool {
-			return nil, ast.PosError(op, "logical operations may only be performed on boolean values")
-		}
-		return a.Bool() && b.Bool(), nil
-	case "||":
-		if a.Kind() != reflect.Bool || b.Kind() != reflect.Bool {
-			return nil, ast.PosError(op, "logical operations may only be performed on boolean values")
-		}
-		return a.Bool() || b.Bool(), nil
-	case ">=":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() >= b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() >= b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() >= b.Float(), nil
-		}
-	case "<=":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() <= b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() <= b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() <= b.Float(), nil
-		}
-	case ">":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() > b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() > b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() > b.Float(), nil
-		}
-	case "<":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() < b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() < b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() < b.Float(), nil
-		}
 	case "+":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() + b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() + b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() + b.Float(), nil
-		case reflect.String:
+		if a.Kind() == reflect.String {
 			return a.String() + b.String(), nil
 		}
-	case "-":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() - b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() - b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() - b.Float(), nil
-		}
-	case "*":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() * b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() * b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() * b.Float(), nil
-		}
-	case "/":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() / b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() / b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return a.Float() / b.Float(), nil
-		}
-	case "%":
-		switch a.Kind() {
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			return a.Int() % b.Int(), nil
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
-			return a.Uint() % b.Uint(), nil
-		case reflect.Float64, reflect.Float32:
-			return nil, ast.PosError(op, "modulus operation cannot be performed on floats")
+	case "<", "<=", ">", ">=":
+		if a.Kind() == reflect.String {
+			return compareResult(op.Value, strings.Compare(a.String(), b.String())), nil
 		}
 	case "in":
 		if a.Kind() == reflect.String && b.Kind() == reflect.String {
@@ -187,7 +211,10 @@ func handleIn(op ast.Operator, a, b reflect.Value) (c, d reflect.Value, err erro
 
 func handleNil(op ast.Operator, a, b reflect.Value) (any, error) {
 	if !a.IsValid() && !b.IsValid() {
-		return true, nil
+		if op.Value != "==" && op.Value != "!=" {
+			return nil, ast.PosError(op, "invalid operator for nil value (expected == or !=, got %s)", op.Value)
+		}
+		return op.Value == "==", nil
 	} else if !a.IsValid() {
 		return nil, ast.PosError(op, "nil must be on the right side of an expression")
 	} else if !b.IsValid() {
@@ -1,6 +1,8 @@
 package salix
 
 import (
+	"io"
+	"strings"
 	"testing"
 )
 
@@ -143,3 +145,158 @@ func TestAssignment(t *testing.T) {
 		t.Errorf("Expected %q, got %q", "4", res)
 	}
 }
+
+func TestShortCircuit(t *testing.T) {
+	type user struct{ IsAdmin bool }
+
+	calls := 0
+	inc := func() bool {
+		calls++
+		return true
+	}
+
+	testCases := []struct {
+		expr     string
+		expected string
+		calls    int
+	}{
+		{`false && inc()`, "false", 0},
+		{`true || inc()`, "true", 0},
+		{`true && inc()`, "true", 1},
+		{`false || inc()`, "true", 1},
+		{`false && inc() || inc()`, "true", 1},
+		{`user != nil && user.IsAdmin`, "false", 0},
+		{`user == nil || user.IsAdmin`, "true", 0},
+		{`guest != nil && guest.IsAdmin`, "false", 0},
+		{`guest == nil || guest.IsAdmin`, "true", 0},
+		{`true ? "yes" : inc()`, "yes", 0},
+		{`false ? inc() : "no"`, "no", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			calls = 0
+			res := execStr(t, "#("+tc.expr+")", map[string]any{"inc": inc, "user": (*user)(nil), "guest": nil})
+			if res != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, res)
+			}
+			if calls != tc.calls {
+				t.Errorf("Expected %d calls, got %d", tc.calls, calls)
+			}
+		})
+	}
+}
+
+func TestLogicalNonBool(t *testing.T) {
+	for _, expr := range []string{`1 && true`, `true && "x"`, `false || 0`} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := evalStr(t, expr, nil)
+			if err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestNilComparison(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected any
+	}{
+		{`none == nil`, true},
+		{`none != nil`, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			val, err := evalStr(t, tc.expr, map[string]any{"none": nil})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if val != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, val)
+			}
+		})
+	}
+
+	_, err := evalStr(t, `none < nil`, map[string]any{"none": nil})
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+type truthyTest bool
+
+func (tt truthyTest) Truthy() bool {
+	return bool(tt)
+}
+
+type emptyTest struct{ items []int }
+
+func (et *emptyTest) Truthy() bool {
+	return len(et.items) > 0
+}
+
+func TestTruthiness(t *testing.T) {
+	vars := map[string]any{
+		"emptyStr":   "",
+		"str":        "x",
+		"zero":       0,
+		"num":        1.5,
+		"ptr":        (*int)(nil),
+		"emptySlice": []int{},
+		"slice":      []int{1},
+		"emptyMap":   map[string]int{},
+		"none":       nil,
+		"struct":     struct{}{},
+		"truthy":     truthyTest(false),
+		"emptyTest":  &emptyTest{},
+	}
+
+	testCases := []struct {
+		tmpl     string
+		expected string
+	}{
+		{`#if(emptyStr):a#else:b#!if`, "b"},
+		{`#if(str):a#else:b#!if`, "a"},
+		{`#if(zero):a#elif(num):b#!if`, "b"},
+		{`#if(ptr || emptySlice || emptyMap || none):a#else:b#!if`, "b"},
+		{`#if(slice && struct):a#else:b#!if`, "a"},
+		{`#(!zero) #(!str)`, "true false"},
+		{`#(emptyStr ? "a" : "b")`, "b"},
+		{`#(truthy ? "a" : "b")`, "b"},
+		{`#(emptyTest ? "a" : "b")`, "b"},
+	}
+
+	ns := New().WithTruthiness(true)
+	for _, tc := range testCases {
+		t.Run(tc.tmpl, func(t *testing.T) {
+			tmpl, err := ns.ParseString("test", tc.tmpl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sb := &strings.Builder{}
+			err = tmpl.WithVarMap(vars).Execute(sb)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sb.String() != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, sb.String())
+			}
+		})
+	}
+}
+
+func TestTruthinessDisabled(t *testing.T) {
+	tmpl, err := New().ParseString("test", `#if(x):a#!if`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"x": "str"}).Execute(io.Discard)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
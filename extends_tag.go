@@ -0,0 +1,168 @@
+package salix
+
+import "go.elara.ws/salix/ast"
+
+// extendsTag represents an #extends tag within a Salix template.
+// Templates that extend another template are resolved before they're
+// executed, so this only runs if the tag isn't at the top level.
+type extendsTag struct{}
+
+func (et extendsTag) Run(tc *TagContext, block, args []ast.Node) error {
+	return tc.PosError(tc.Tag, "extends tags must be at the top level of a template")
+}
+
+// blockTag represents a #block tag within a Salix template
+type blockTag struct{}
+
+func (bt blockTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if len(args) != 1 {
+		return tc.PosError(tc.Tag, "expected one argument, got %d", len(args))
+	}
+
+	val, err := tc.GetValue(args[0], nil)
+	if err != nil {
+		return err
+	}
+
+	name, ok := val.(string)
+	if !ok {
+		return tc.PosError(args[0], "invalid argument type: %T (expected string)", val)
+	}
+
+	// The definitions of the block are ordered from the most derived template
+	// to the base template, so the first one is rendered, and super() renders
+	// the next one.
+	overrides := tc.t.blocks[name]
+	defs := make([][]ast.Node, 0, len(overrides)+1)
+	defs = append(append(defs, overrides...), block)
+	return tc.Execute(defs[0], map[string]any{"super": bt.superFunc(tc, defs[1:])})
+}
+
+// superFunc returns the function used as the super variable within a block,
+// which renders the next definition of the block in the inheritance chain.
+func (bt blockTag) superFunc(tc *TagContext, defs [][]ast.Node) func() (HTML, error) {
+	return func() (HTML, error) {
+		if len(defs) == 0 {
+			return "", nil
+		}
+		out, err := tc.ExecuteToMemory(defs[0], map[string]any{"super": bt.superFunc(tc, defs[1:])})
+		return HTML(out), err
+	}
+}
+
+// resolveInheritance follows the chain of #extends tags starting with nodes,
+// collecting the blocks defined by each template along the way, and returns
+// the nodes of the base template at the end of the chain, preceded by the
+// top-level definitions of the templates that extend it. If nodes doesn't
+// extend another template, it's returned as-is.
+func (t *Template) resolveInheritance(name string, nodes []ast.Node) ([]ast.Node, error) {
+	var defs []ast.Node
+	seen := map[string]bool{name: true}
+	for {
+		ext, ok := findExtends(nodes)
+		if !ok {
+			if defs == nil {
+				return nodes, nil
+			}
+			return append(defs, nodes...), nil
+		}
+
+		if len(ext.Params) != 1 {
+			return nil, ast.PosError(ext, "expected one argument, got %d", len(ext.Params))
+		}
+
+		val, err := t.getValue(ext.Params[0], nil)
+		if err != nil {
+			return nil, err
+		}
+
+		parentName, ok := val.(string)
+		if !ok {
+			return nil, ast.PosError(ext.Params[0], "invalid argument type: %T (expected string)", val)
+		}
+
+		if seen[parentName] {
+			return nil, ast.PosError(ext.Params[0], "inheritance cycle: %q extends itself", parentName)
+		}
+		seen[parentName] = true
+
+		parent, ok := t.ns.GetTemplate(parentName)
+		if !ok {
+			return nil, ast.PosError(ext.Params[0], "no such template: %q", parentName)
+		}
+
+		t.collectBlocks(nodes)
+		// Definitions from more derived templates are executed after
+		// the ones from the templates they extend, so they take precedence.
+		defs = append(collectDefinitions(nodes), defs...)
+		nodes = parent.ast
+	}
+}
+
+// collectDefinitions returns the top-level macro definitions, #set and #let tags,
+// and assignments in nodes, so that they can be executed before the base template.
+// Everything else outside of #block tags is ignored in templates that extend another one.
+func collectDefinitions(nodes []ast.Node) []ast.Node {
+	var out []ast.Node
+	depth := 0
+	start := -1
+	for i, node := range nodes {
+		if depth == 0 {
+			switch node := node.(type) {
+			case ast.Tag:
+				switch {
+				case node.Name.Value == "macro" && node.HasBody:
+					start = i
+				case node.Name.Value == "set", node.Name.Value == "let" && !node.HasBody:
+					out = append(out, node)
+				}
+			case ast.ExprTag:
+				if _, ok := node.Value.(ast.Assignment); ok {
+					out = append(out, node)
+				}
+			}
+		}
+
+		depth += depthChange(node)
+		if start >= 0 && depth == 0 {
+			out = append(out, nodes[start:i+1]...)
+			start = -1
+		}
+	}
+	return out
+}
+
+// findExtends finds the #extends tag at the top level of nodes
+func findExtends(nodes []ast.Node) (ast.Tag, bool) {
+	depth := 0
+	for _, node := range nodes {
+		if tag, ok := node.(ast.Tag); ok && depth == 0 && tag.Name.Value == "extends" {
+			return tag, true
+		}
+		depth += depthChange(node)
+	}
+	return ast.Tag{}, false
+}
+
+// collectBlocks adds the definitions of all the blocks in nodes, including
+// nested ones, to the template's block map. Since templates are processed from
+// the most derived one to the base, definitions are appended after any existing
+// ones, which come from templates that extend this one.
+func (t *Template) collectBlocks(nodes []ast.Node) {
+	for i, node := range nodes {
+		tag, ok := node.(ast.Tag)
+		if !ok || tag.Name.Value != "block" || !tag.HasBody || len(tag.Params) != 1 {
+			continue
+		}
+
+		// Blocks are identified by string literals, so that they
+		// can be collected without executing the template.
+		name, ok := unwrap(tag.Params[0]).(ast.String)
+		if !ok {
+			continue
+		}
+
+		block := t.getBlock(nodes, i+1, tag.Position.Line, "block")
+		t.blocks[name.Value] = append(t.blocks[name.Value], block)
+	}
+}
@@ -0,0 +1,176 @@
+package salix
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestExtends(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("base.html", `<title>#block("title"):Site#!block</title><main>#block("content"):default#!block</main>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("page.html", `#extends("base.html")
+ignored
+#block("title"):#(page) | #(super())#!block
+#block("content"):Hello, #(name)!#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.WithVarMap(map[string]any{"page": "Home", "name": "World"}).Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "<title>Home | Site</title><main>Hello, World!</main>"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestExtendsMultiLevel(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("base.html", `[#block("content"):base#!block]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("layout.html", `#extends("base.html")#block("content"):layout(#block("sidebar"):sidebar#!block) #(super())#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("page.html", `#extends("layout.html")#block("sidebar"):page #(super())#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "[layout(page sidebar) base]"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestExtendsInclude(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("base.html", `<#block("content"):base#!block>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("widget.html", `#extends("base.html")#block("content"):widget#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("page.html", `#extends("base.html")#block("content"):page #include("widget.html")#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "<page <widget>>"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestExtendsDefinitions(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("base.html", `[#block("content"):#!block]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("layout.html", `#extends("base.html")#let(x = "layout", y = "layout")#macro("m"):layout#!macro`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("page.html", `#extends("layout.html")
+ignored
+#macro("m"):M(#(x))#!macro
+#set(x = "page")
+#(z = 1)
+#block("content"):#macro("m") #(y) #(z)#!block`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "[M(page) layout 1]"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestExtendsErrors(t *testing.T) {
+	ns := New()
+
+	_, err := ns.ParseString("a.html", `#extends("b.html")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("b.html", `#extends("a.html")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("missing.html", `#extends("nonexistent.html")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ns.ParseString("nested.html", `#if(true):#extends("a.html")#!if`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"a.html", `b.html: line 1, col 10: inheritance cycle: "a.html" extends itself`},
+		{"missing.html", `missing.html: line 1, col 10: no such template: "nonexistent.html"`},
+		{"nested.html", "extends tags must be at the top level of a template"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ns.MustGetTemplate(tc.name).Execute(io.Discard)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
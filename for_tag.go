@@ -1,12 +1,75 @@
 package salix
 
 import (
-	"errors"
+	"cmp"
+	"fmt"
+	"iter"
+	"math"
+	"math/rand"
 	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"go.elara.ws/salix/ast"
 )
 
+// MapOrder represents the order in which #for tags iterate over maps
+type MapOrder uint8
+
+const (
+	// MapOrderSorted iterates over maps in ascending order of their keys.
+	// This is the default, since it makes the output deterministic.
+	MapOrderSorted MapOrder = iota
+	// MapOrderRandom iterates over maps in a random order
+	MapOrderRandom
+)
+
+// OrderedMap can be implemented by map types that have their own iteration order,
+// such as insertion order. #for tags iterate over them using Range, which should call
+// fn for each entry in the map, and stop if fn returns false. Ordered maps are always
+// iterated in their own order, regardless of the namespace's MapOrder setting.
+type OrderedMap interface {
+	Range(fn func(key, value any) bool)
+}
+
+// Loop contains information about the current iteration of a #for tag.
+// It's available as the loop variable within the tag's block.
+type Loop struct {
+	// Index is the zero-based index of the current iteration
+	Index int
+	// Index1 is the one-based index of the current iteration
+	Index1 int
+	// RevIndex is the amount of iterations left after the current one,
+	// so it's zero on the last iteration. It's -1 if Length is unknown.
+	RevIndex int
+	// First is true if this is the first iteration
+	First bool
+	// Last is true if this is the last iteration. It's always false
+	// if Length is unknown.
+	Last bool
+	// Length is the total amount of iterations. It's -1 if it can't be known
+	// in advance, such as when iterating over a channel or an iterator function.
+	Length int
+	// Parent contains information about the enclosing loop when
+	// #for tags are nested. It's nil in the outermost loop.
+	Parent *Loop
+}
+
+// setIndex updates the loop information for the iteration with index i
+func (l *Loop) setIndex(i int) {
+	l.Index = i
+	l.Index1 = i + 1
+	l.First = i == 0
+	if l.Length >= 0 {
+		l.RevIndex = l.Length - i - 1
+		l.Last = i == l.Length-1
+	} else {
+		l.RevIndex = -1
+		l.Last = false
+	}
+}
+
 // forTag represents a #for tag within a Salix template
 type forTag struct{}
 
@@ -20,24 +83,22 @@ func (ft forTag) Run(tc *TagContext, block, args []ast.Node) error {
 		return tc.PosError(args[0], "invalid argument type: %T (expected ast.Expr)", args[0])
 	}
 
-	var vars []string
+	var vars []forVar
 	var in reflect.Value
 
-	if len(args) > 1 {
-		for _, arg := range args[:len(args)-1] {
-			varName, ok := unwrap(arg).(ast.Ident)
-			if !ok {
-				return tc.PosError(arg, "invalid argument type: %T (expected ast.Ident)", expr.First)
-			}
-			vars = append(vars, varName.Value)
+	for _, arg := range args[:len(args)-1] {
+		v, err := ft.parseVar(tc, arg)
+		if err != nil {
+			return err
 		}
+		vars = append(vars, v)
 	}
 
-	varName, ok := unwrap(expr.First).(ast.Ident)
-	if !ok {
-		return tc.PosError(expr.First, "invalid argument type: %T (expected ast.Ident)", args[0])
+	v, err := ft.parseVar(tc, expr.First)
+	if err != nil {
+		return err
 	}
-	vars = append(vars, varName.Value)
+	vars = append(vars, v)
 
 	if len(expr.Rest) != 1 {
 		return tc.PosError(expr.First, "invalid expression (expected 1 element, got %d)", len(expr.Rest))
@@ -54,52 +115,350 @@ func (ft forTag) Run(tc *TagContext, block, args []ast.Node) error {
 	}
 	in = reflect.ValueOf(val)
 
+	var empty []ast.Node
+	if markers := findMarkers(block, "empty", "else"); len(markers) > 1 {
+		return tc.PosError(block[markers[1]], "cannot have more than one empty or else tag in a for tag")
+	} else if len(markers) == 1 {
+		empty = block[markers[0]+1:]
+		block = block[:markers[0]]
+	}
+
+	loop := &Loop{}
+	if parent, ok := tc.local.get("loop"); ok {
+		loop.Parent, _ = parent.(*Loop)
+	}
+
+	seq, length, keyed, err := ft.toSeq(tc, val, in)
+	if err != nil {
+		return tc.PosError(rest.First, "%w", err)
+	}
+
+	if len(vars) == 3 && !keyed {
+		return tc.PosError(vars[0].node, "only maps and iter.Seq2 functions can use three for loop variables, got %T", val)
+	}
+	loop.Length = length
+
+	n, err := ft.iterate(tc, block, vars, loop, seq)
+	if err != nil {
+		return err
+	}
+
+	if n == 0 && empty != nil {
+		return tc.Execute(empty, nil)
+	}
+
+	return nil
+}
+
+// toSeq converts the value being iterated over into a sequence of key/value pairs.
+// It also returns the amount of items in the sequence, or -1 if it can't be known
+// in advance, and whether the keys are map keys rather than indices.
+func (ft forTag) toSeq(tc *TagContext, val any, in reflect.Value) (seq iter.Seq2[any, any], length int, keyed bool, err error) {
+	// Ordered maps define their own iteration order,
+	// so they're handled before any other types.
+	if om, ok := val.(OrderedMap); ok {
+		var keys, vals []any
+		om.Range(func(key, val any) bool {
+			keys = append(keys, key)
+			vals = append(vals, val)
+			return true
+		})
+
+		return func(yield func(any, any) bool) {
+			for i := range keys {
+				if !yield(keys[i], vals[i]) {
+					return
+				}
+			}
+		}, len(keys), true, nil
+	}
+
 	switch in.Kind() {
+	case reflect.Invalid:
+		return func(yield func(any, any) bool) {}, 0, false, nil
 	case reflect.Slice, reflect.Array:
-		local := map[string]any{}
-		for i := 0; i < in.Len(); i++ {
-			if len(vars) == 1 {
-				local[vars[0]] = in.Index(i).Interface()
-			} else if len(vars) == 2 {
-				local[vars[0]] = i
-				local[vars[1]] = in.Index(i).Interface()
-			} else {
-				return errors.New("slices and arrays can only use two for loop variables")
+		return func(yield func(any, any) bool) {
+			for i := 0; i < in.Len(); i++ {
+				if !yield(i, in.Index(i).Interface()) {
+					return
+				}
 			}
+		}, in.Len(), false, nil
+	case reflect.Map:
+		keys := in.MapKeys()
+		if tc.t.ns.MapOrder == MapOrderRandom {
+			rand.Shuffle(len(keys), func(i, j int) {
+				keys[i], keys[j] = keys[j], keys[i]
+			})
+		} else {
+			sortMapKeys(keys)
+		}
 
-			err = tc.Execute(block, local)
-			if err != nil {
-				return err
+		return func(yield func(any, any) bool) {
+			for _, key := range keys {
+				if !yield(key.Interface(), in.MapIndex(key).Interface()) {
+					return
+				}
 			}
+		}, len(keys), true, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n int
+		if in.CanInt() {
+			n = int(max(in.Int(), 0))
+		} else if in.Uint() > math.MaxInt {
+			return nil, 0, false, fmt.Errorf("value %d is too large to iterate over", in.Uint())
+		} else {
+			n = int(in.Uint())
 		}
-	case reflect.Map:
-		local := map[string]any{}
-		iter := in.MapRange()
-		i := 0
-		for iter.Next() {
-			if len(vars) == 1 {
-				local[vars[0]] = iter.Value().Interface()
-			} else if len(vars) == 2 {
-				local[vars[0]] = iter.Key().Interface()
-				local[vars[1]] = iter.Value().Interface()
-			} else if len(vars) == 3 {
-				local[vars[0]] = i
-				local[vars[1]] = iter.Key().Interface()
-				local[vars[2]] = iter.Value().Interface()
+
+		return func(yield func(any, any) bool) {
+			for i := 0; i < n; i++ {
+				if !yield(i, i) {
+					return
+				}
+			}
+		}, n, false, nil
+	case reflect.String:
+		str := in.String()
+		return func(yield func(any, any) bool) {
+			for i, char := range str {
+				if !yield(i, string(char)) {
+					return
+				}
+			}
+		}, utf8.RuneCountInString(str), false, nil
+	case reflect.Chan:
+		if in.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, 0, false, fmt.Errorf("cannot iterate over send-only channel %s", in.Type())
+		}
+
+		return func(yield func(any, any) bool) {
+			for i := 0; ; i++ {
+				item, ok := in.Recv()
+				if !ok || !yield(i, item.Interface()) {
+					return
+				}
+			}
+		}, -1, false, nil
+	case reflect.Func:
+		if in.Type().CanSeq2() {
+			return func(yield func(any, any) bool) {
+				for key, val := range in.Seq2() {
+					if !yield(key.Interface(), val.Interface()) {
+						return
+					}
+				}
+			}, -1, true, nil
+		} else if in.Type().CanSeq() {
+			return func(yield func(any, any) bool) {
+				i := 0
+				for item := range in.Seq() {
+					if !yield(i, item.Interface()) {
+						return
+					}
+					i++
+				}
+			}, -1, false, nil
+		}
+	}
+
+	return nil, 0, false, fmt.Errorf("cannot iterate over value of type %T", val)
+}
+
+// iterate executes the for tag's block for each item in seq, and returns the
+// amount of iterations that were executed. Items are only read from seq when
+// they're about to be executed, so no items are consumed after a #break, and
+// streaming producers such as channels are rendered as soon as items arrive.
+func (ft forTag) iterate(tc *TagContext, block []ast.Node, vars []forVar, loop *Loop, seq iter.Seq2[any, any]) (int, error) {
+	i := 0
+	for key, val := range seq {
+		loop.setIndex(i)
+		i++
+		stop, err := handleLoopSignal(ft.execItem(tc, block, vars, loop, key, val))
+		if stop {
+			return i, err
+		}
+	}
+	return i, nil
+}
+
+// forVar represents a variable assigned by a for tag. If names contains
+// more than one name, values are destructured into them.
+type forVar struct {
+	node        ast.Node
+	names       []string
+	destructure bool
+}
+
+// parseVar parses a for tag variable, which can be either an identifier,
+// or an array of identifiers that the value should be destructured into.
+func (ft forTag) parseVar(tc *TagContext, node ast.Node) (forVar, error) {
+	switch n := unwrap(node).(type) {
+	case ast.Ident:
+		return forVar{node: node, names: []string{n.Value}}, nil
+	case ast.Array:
+		if len(n.Array) == 0 {
+			return forVar{}, tc.PosError(node, "cannot destructure into zero variables")
+		}
+		out := forVar{node: node, destructure: true}
+		for _, elem := range n.Array {
+			name, ok := unwrap(elem).(ast.Ident)
+			if !ok {
+				return forVar{}, tc.PosError(elem, "invalid destructuring target: %T (expected ast.Ident)", unwrap(elem))
 			}
+			out.names = append(out.names, name.Value)
+		}
+		return out, nil
+	default:
+		return forVar{}, tc.PosError(node, "invalid argument type: %T (expected ast.Ident or ast.Array)", unwrap(node))
+	}
+}
 
-			err = tc.Execute(block, local)
+// assign sets the variables described by v to val in local,
+// destructuring val if needed.
+func (v forVar) assign(tc *TagContext, local map[string]any, val any) error {
+	if !v.destructure {
+		local[v.names[0]] = val
+		return nil
+	}
+
+	rval := reflect.ValueOf(val)
+	for rval.Kind() == reflect.Pointer || rval.Kind() == reflect.Interface {
+		rval = rval.Elem()
+	}
+
+	switch rval.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rval.Len() != len(v.names) {
+			return tc.PosError(v.node, "cannot destructure %d values into %d variables", rval.Len(), len(v.names))
+		}
+		for i, name := range v.names {
+			local[name] = rval.Index(i).Interface()
+		}
+	case reflect.Struct:
+		for _, name := range v.names {
+			field, err := v.structField(tc, rval, name)
 			if err != nil {
 				return err
 			}
-
-			i++
+			local[name] = field.Interface()
+		}
+	case reflect.Map:
+		if rval.Type().Key().Kind() != reflect.String {
+			return tc.PosError(v.node, "cannot destructure map with non-string keys: %s", rval.Type())
 		}
+		for _, name := range v.names {
+			item := rval.MapIndex(reflect.ValueOf(name).Convert(rval.Type().Key()))
+			if !item.IsValid() {
+				return tc.PosError(v.node, "cannot destructure map: no such key: %q", name)
+			}
+			local[name] = item.Interface()
+		}
+	default:
+		return tc.PosError(v.node, "cannot destructure value of type %T", val)
 	}
 
 	return nil
 }
 
+// structField gets the field of rval that a destructuring target refers to.
+// Exact matches are preferred, but the field name is matched case-insensitively
+// if there aren't any, so that the variables can use the usual lowercase names.
+func (v forVar) structField(tc *TagContext, rval reflect.Value, name string) (reflect.Value, error) {
+	index, ok := tc.t.ns.types.fieldIndex(rval.Type(), name)
+	if !ok {
+		field, found := rval.Type().FieldByNameFunc(func(fieldName string) bool {
+			return strings.EqualFold(fieldName, name)
+		})
+		if !found {
+			return reflect.Value{}, tc.PosError(v.node, "cannot destructure %s: no such field: %s", rval.Type(), name)
+		}
+		index = field.Index
+	}
+
+	field, err := rval.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}, tc.PosError(v.node, "cannot destructure %s: %w", rval.Type(), err)
+	} else if !field.CanInterface() {
+		return reflect.Value{}, tc.PosError(v.node, "cannot destructure %s: field %s is unexported", rval.Type(), name)
+	}
+	return field, nil
+}
+
+// execItem executes the for tag's block for a single item. With one variable, it's set
+// to val. With two, they're set to key and val. With three, they're set to the index,
+// key, and val, which is only allowed for keyed sequences such as maps.
+func (ft forTag) execItem(tc *TagContext, block []ast.Node, vars []forVar, loop *Loop, key, val any) error {
+	// Each iteration gets its own copy of the loop information,
+	// so that references to it don't change in later iterations.
+	cur := *loop
+	local := map[string]any{"loop": &cur}
+
+	var values []any
+	switch len(vars) {
+	case 1:
+		values = []any{val}
+	case 2:
+		values = []any{key, val}
+	case 3:
+		values = []any{cur.Index, key, val}
+	}
+
+	for i, v := range vars {
+		if err := v.assign(tc, local, values[i]); err != nil {
+			return err
+		}
+	}
+
+	return tc.Execute(block, local)
+}
+
+// sortMapKeys sorts map keys in ascending order. Strings, numbers, times, and
+// types that implement Comparer are compared directly. If any of the keys can't
+// be compared that way, all the keys are sorted by their string representations
+// instead, with ties broken by their types, their Go-syntax representations, and
+// then, for pointers and channels, their addresses, so that the order is still
+// deterministic. Keys that are still equal after that, such as NaN floats, print
+// the same way, so their order doesn't affect the output.
+func sortMapKeys(keys []reflect.Value) {
+	var cmpErr error
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		c, err := compareValues(a, b)
+		if err != nil {
+			cmpErr = err
+		}
+		return c
+	})
+
+	if cmpErr != nil {
+		slices.SortStableFunc(keys, func(a, b reflect.Value) int {
+			ai, bi := a.Interface(), b.Interface()
+			return cmp.Or(
+				strings.Compare(fmt.Sprint(ai), fmt.Sprint(bi)),
+				strings.Compare(fmt.Sprintf("%T", ai), fmt.Sprintf("%T", bi)),
+				strings.Compare(fmt.Sprintf("%#v", ai), fmt.Sprintf("%#v", bi)),
+				cmp.Compare(keyAddr(a), keyAddr(b)),
+			)
+		})
+	}
+}
+
+// keyAddr returns the address that v points to if it's a pointer or a channel,
+// or zero otherwise.
+func keyAddr(v reflect.Value) uintptr {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return v.Pointer()
+	default:
+		return 0
+	}
+}
+
 func unwrap(n ast.Node) ast.Node {
 	if v, ok := n.(ast.Value); ok {
 		return v.Node
@@ -21,7 +21,7 @@ func TestFuncCall(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	val, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	val, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if err != nil {
 		t.Fatalf("execFuncCall error: %s", err)
 	}
@@ -44,7 +44,7 @@ func TestFuncCallInvalidParamCount(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -63,7 +63,7 @@ func TestFuncCallInvalidParamType(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -84,7 +84,7 @@ func TestFuncCallVariadic(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	val, err := tmpl.execFuncCall(ast, map[string]any{"concat": concat})
+	val, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"concat": concat}))
 	if err != nil {
 		t.Fatalf("execFuncCall error: %s", err)
 	}
@@ -105,7 +105,7 @@ func TestFuncCallError(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if !errors.Is(err, expectedErr) {
 		t.Errorf("Expected %q, got %q", expectedErr, err)
 	}
@@ -122,7 +122,7 @@ func TestFuncCallMultiReturn(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	val, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	val, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if err != nil {
 		t.Fatalf("execFuncCall error: %s", err)
 	}
@@ -143,7 +143,7 @@ func TestFuncCallMultiReturnError(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if !errors.Is(err, expectedErr) {
 		t.Errorf("Expected %q, got %q", expectedErr, err)
 	}
@@ -157,7 +157,7 @@ func TestFuncCallNil(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": nil})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": nil}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -171,7 +171,7 @@ func TestFuncCallNotFound(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -194,13 +194,13 @@ func TestFuncCallAssignment(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	_, err := tmpl.execFuncCall(ast, map[string]any{"test": fn})
+	_, err := tmpl.execFuncCall(ast, newScope(nil, map[string]any{"test": fn}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
 }
 
-func TestValidateFunc(t *testing.T) {
+func TestCheckFuncType(t *testing.T) {
 	testCases := []reflect.Type{
 		reflect.TypeFor[func()](),               // Template functions must return at least one value
 		reflect.TypeFor[func() (x, y int)](),    // Second return value must be an error
@@ -209,7 +209,7 @@ func TestValidateFunc(t *testing.T) {
 
 	for index, testCase := range testCases {
 		t.Run(fmt.Sprint(index), func(t *testing.T) {
-			err := validateFunc(testCase, ast.Bool{Value: true, Position: testPos(t)})
+			err := checkFuncType(testCase)
 			if err == nil {
 				t.Error("Expected error, got nil")
 			}
@@ -1,3 +1,3 @@
 module go.elara.ws/salix
 
-go 1.21.2
+go 1.23
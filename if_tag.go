@@ -20,7 +20,7 @@ func (it ifTag) Run(tc *TagContext, block, args []ast.Node) error {
 		return err
 	}
 
-	cond, ok := val.(bool)
+	cond, ok := tc.IsTrue(val)
 	if !ok {
 		return tc.PosError(args[0], "expected boolean argument, got %T", val)
 	}
@@ -35,7 +35,7 @@ func (it ifTag) Run(tc *TagContext, block, args []ast.Node) error {
 					return err
 				}
 
-				cond, ok := val.(bool)
+				cond, ok := tc.IsTrue(val)
 				if !ok {
 					return tc.PosError(elifTag.value, "expected boolean argument, got %T", val)
 				}
@@ -75,16 +75,15 @@ type elif struct {
 // findInner finds the inner elif and else tags in a block
 // passed to the if tag.
 func (it ifTag) findInner(tc *TagContext, block []ast.Node) (innerTags, error) {
-	// Depth keeps track of nested if statements. We only want to look for
-	// else/elif tags within the current if tag, not any nested ones.
+	// Depth keeps track of nested tags. We only want to look for else/elif
+	// tags within the current if tag, not any nested ones, such as an
+	// else tag inside a nested for tag.
 	depth := 0
 	var out innerTags
 	for i, node := range block {
-		switch tag := node.(type) {
-		case ast.Tag:
+		depth += depthChange(node)
+		if tag, ok := node.(ast.Tag); ok {
 			switch tag.Name.Value {
-			case "if":
-				depth++
 			case "elif":
 				if depth != 0 {
 					continue
@@ -112,10 +111,6 @@ func (it ifTag) findInner(tc *TagContext, block []ast.Node) (innerTags, error) {
 				out.elseIndex = i
 				break
 			}
-		case ast.EndTag:
-			if tag.Name.Value == "if" {
-				depth--
-			}
 		}
 	}
 	if out.endRoot == 0 {
@@ -60,5 +60,19 @@ func (it includeTag) Run(tc *TagContext, block, args []ast.Node) error {
 		}
 	}
 
-	return tc.Execute(tmpl.ast, local)
+	nodes := tmpl.ast
+	if _, ok := findExtends(nodes); ok {
+		// The included template's blocks shouldn't affect the blocks
+		// of the template that included it, so it gets its own block map.
+		blocks := tc.t.blocks
+		tc.t.blocks = map[string][][]ast.Node{}
+		defer func() { tc.t.blocks = blocks }()
+
+		nodes, err = tc.t.resolveInheritance(name, nodes)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tc.Execute(nodes, local)
 }
@@ -0,0 +1,46 @@
+package salix
+
+import "go.elara.ws/salix/ast"
+
+// LazyValue is a variable value that's only computed when a template
+// accesses it. Use Lazy to create one.
+type LazyValue struct {
+	fn func() (any, error)
+}
+
+// Lazy returns a value that calls fn the first time a template accesses it.
+// The result is cached for the rest of the execution, including within included
+// templates and macros, so fn is called at most once per Execute call.
+func Lazy(fn func() (any, error)) *LazyValue {
+	return &LazyValue{fn: fn}
+}
+
+type lazyResult struct {
+	val any
+	err error
+}
+
+// resolveLazy returns the underlying value of v if it's a *LazyValue,
+// computing it if it hasn't been computed yet during this execution.
+// Otherwise, v is returned as-is.
+func (t *Template) resolveLazy(id ast.Ident, v any) (any, error) {
+	lv, ok := v.(*LazyValue)
+	if !ok || lv == nil {
+		return v, nil
+	}
+
+	if t.lazy == nil {
+		t.lazy = map[*LazyValue]lazyResult{}
+	}
+
+	res, ok := t.lazy[lv]
+	if !ok {
+		res.val, res.err = lv.fn()
+		t.lazy[lv] = res
+	}
+
+	if res.err != nil {
+		return nil, ast.PosError(id, "%s: %w", id.Value, res.err)
+	}
+	return res.val, nil
+}
@@ -0,0 +1,75 @@
+package salix
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLazy(t *testing.T) {
+	calls := 0
+	user := Lazy(func() (any, error) {
+		calls++
+		return "admin", nil
+	})
+
+	ns := New()
+
+	_, err := ns.ParseString("inner.html", `#(user)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := ns.ParseString("test", `#(user) #include("inner.html") #macro("m"):#(user)#!macro#macro("m")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl = tmpl.WithVarMap(map[string]any{"user": user})
+	for i := 1; i <= 2; i++ {
+		sb := &strings.Builder{}
+		err = tmpl.Execute(sb)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sb.String() != "admin admin admin" {
+			t.Errorf("Expected %q, got %q", "admin admin admin", sb.String())
+		}
+
+		if calls != i {
+			t.Errorf("Expected %d calls, got %d", i, calls)
+		}
+	}
+}
+
+func TestLazyUnused(t *testing.T) {
+	calls := 0
+	lazy := Lazy(func() (any, error) {
+		calls++
+		return nil, nil
+	})
+
+	execStr(t, `Hello`, map[string]any{"unused": lazy})
+	if calls != 0 {
+		t.Errorf("Expected 0 calls, got %d", calls)
+	}
+}
+
+func TestLazyError(t *testing.T) {
+	errTest := errors.New("test error")
+	lazy := Lazy(func() (any, error) {
+		return nil, errTest
+	})
+
+	tmpl, err := New().ParseString("test", `#(x)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"x": lazy}).Execute(io.Discard)
+	if !errors.Is(err, errTest) {
+		t.Errorf("Expected error to wrap %q, got %v", errTest, err)
+	}
+}
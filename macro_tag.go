@@ -2,6 +2,22 @@ package salix
 
 import "go.elara.ws/salix/ast"
 
+// macro represents a macro defined using a #macro tag
+type macro struct {
+	block []ast.Node
+	// params contains the macro's declared parameters. If it's nil,
+	// the macro doesn't declare any, so it accepts any named arguments.
+	params []macroParam
+}
+
+// macroParam represents a parameter declared by a macro
+type macroParam struct {
+	name string
+	// def is the parameter's default value. If it's nil,
+	// an argument must be provided for the parameter.
+	def ast.Node
+}
+
 // macroTag represents an #macro tag within a Salix template
 type macroTag struct{}
 
@@ -26,35 +42,139 @@ func (mt macroTag) Run(tc *TagContext, block, args []ast.Node) error {
 		ignoreMissing = true
 	}
 
-	if len(block) == 0 {
-		local := map[string]any{}
-
-		// Use the variable assignments after the first argument
-		// to set the local variables of the execution
-		for _, arg := range args[1:] {
-			if a, ok := arg.(ast.Assignment); ok {
-				val, err := tc.GetValue(a.Value, local)
-				if err != nil {
-					return err
-				}
-				local[a.Name.Value] = val
-			} else {
-				// If the argument isn't an assigment, return invalid args
-				return tc.PosError(arg, "%s: invalid argument type: %T (expected ast.Assignment)", tc.NodeToString(arg), arg)
-			}
-		}
-
-		macro, ok := tc.t.macros[name]
+	if !tc.Tag.HasBody {
+		m, ok := tc.t.macros[name]
 		if !ok {
 			if ignoreMissing {
 				return nil
 			}
 			return tc.PosError(tc.Tag, "no such macro: %q", name)
 		}
-		return tc.Execute(macro, local)
-	} else {
-		tc.t.macros[name] = block
+
+		local, err := tc.t.bindMacroArgs(tc.Tag, name, m, args[1:], tc.local)
+		if err != nil {
+			return err
+		}
+		return tc.Execute(m.block, local)
+	}
+
+	params, err := mt.parseParams(tc, args[1:])
+	if err != nil {
+		return err
 	}
+	tc.t.macros[name] = macro{block: block, params: params}
 
 	return nil
 }
+
+// parseParams parses the parameters declared in a macro definition. Identifiers
+// declare required parameters, and assignments declare parameters with defaults.
+func (mt macroTag) parseParams(tc *TagContext, args []ast.Node) ([]macroParam, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	params := make([]macroParam, 0, len(args))
+	seen := map[string]bool{}
+	for _, arg := range args {
+		var param macroParam
+		switch arg := unwrap(arg).(type) {
+		case ast.Ident:
+			param = macroParam{name: arg.Value}
+		case ast.Assignment:
+			param = macroParam{name: arg.Name.Value, def: arg.Value}
+		default:
+			return nil, tc.PosError(arg, "%s: invalid parameter type: %T (expected ast.Ident or ast.Assignment)", tc.NodeToString(arg), arg)
+		}
+
+		if seen[param.name] {
+			return nil, tc.PosError(arg, "duplicate parameter: %s", param.name)
+		}
+		seen[param.name] = true
+
+		if err := tc.t.checkShadow(arg, param.name); err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// bindMacroArgs evaluates the arguments passed to the macro m and returns the local
+// variables its block should be executed with. Positional arguments are bound to the
+// declared parameters in order, followed by named arguments, and then the defaults
+// of any parameters that weren't provided. Arguments are evaluated in the caller's local
+// scope, so they can't be shadowed by parameters. Defaults are evaluated along with the
+// parameters that have already been bound, so they can refer to them.
+//
+// Macros that don't declare any parameters keep the original behavior, in which each
+// named argument can refer to the ones before it.
+func (t *Template) bindMacroArgs(node ast.Node, name string, m macro, args []ast.Node, local *scope) (map[string]any, error) {
+	out := map[string]any{}
+	boundScope := newScope(local, out)
+
+	argScope := local
+	if m.params == nil {
+		argScope = boundScope
+	}
+
+	named := false
+	for i, arg := range args {
+		a, isNamed := arg.(ast.Assignment)
+		if !isNamed {
+			if named {
+				return nil, ast.PosError(arg, "positional arguments cannot follow named arguments")
+			} else if m.params == nil {
+				return nil, ast.PosError(arg, "macro %q doesn't declare any parameters, so it only accepts named arguments", name)
+			} else if i >= len(m.params) {
+				return nil, ast.PosError(arg, "too many arguments for macro %q (expected at most %d)", name, len(m.params))
+			}
+
+			val, err := t.getValue(arg, argScope)
+			if err != nil {
+				return nil, err
+			}
+			out[m.params[i].name] = val
+			continue
+		}
+		named = true
+
+		if m.params != nil && !m.hasParam(a.Name.Value) {
+			return nil, ast.PosError(arg, "macro %q has no parameter named %s", name, a.Name.Value)
+		} else if _, ok := out[a.Name.Value]; ok {
+			return nil, ast.PosError(arg, "multiple values for parameter %s of macro %q", a.Name.Value, name)
+		}
+
+		val, err := t.getValue(a.Value, argScope)
+		if err != nil {
+			return nil, err
+		}
+		out[a.Name.Value] = val
+	}
+
+	for _, param := range m.params {
+		if _, ok := out[param.name]; ok {
+			continue
+		} else if param.def == nil {
+			return nil, ast.PosError(node, "missing argument for parameter %s of macro %q", param.name, name)
+		}
+
+		val, err := t.getValue(param.def, boundScope)
+		if err != nil {
+			return nil, err
+		}
+		out[param.name] = val
+	}
+
+	return out, nil
+}
+
+// hasParam returns true if m declares a parameter with the given name
+func (m macro) hasParam(name string) bool {
+	for _, param := range m.params {
+		if param.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package salix
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"go.elara.ws/salix/ast"
+)
+
+func TestMacro(t *testing.T) {
+	res := execStr(t, `#macro("greet"):Hello, #(name)!#!macro#macro("greet", name = "World")`, nil)
+	if res != "Hello, World!" {
+		t.Errorf("Expected %q, got %q", "Hello, World!", res)
+	}
+}
+
+func TestMacroParams(t *testing.T) {
+	const tmplStr = `#macro("card", title, body = "", footer = "-"):[#(title)|#(body)|#(footer)]#!macro` +
+		`#macro("card", "a")` +
+		`#macro("card", "b", "c")` +
+		`#macro("card", "d", footer = "e")` +
+		`#macro("card", body = "g", title = "f")`
+
+	res := execStr(t, tmplStr, nil)
+	const expected = "[a||-][b|c|-][d||e][f|g|-]"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestMacroArgsUseCallerScope(t *testing.T) {
+	const tmplStr = `#macro("card", title, body = title):[#(title)|#(body)]#!macro` +
+		`#macro("card", "x", title)` +
+		`#macro("card", "x", body = title)` +
+		`#macro("card", "x")`
+
+	res := execStr(t, tmplStr, map[string]any{"title": "CALLER"})
+	const expected = "[x|CALLER][x|CALLER][x|x]"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestMacroNilDefault(t *testing.T) {
+	res := execStr(t, `#macro("m", x = nil):#(x == nil)#!macro#macro("m")`, nil)
+	if res != "true" {
+		t.Errorf("Expected %q, got %q", "true", res)
+	}
+}
+
+func TestMacroParamErrors(t *testing.T) {
+	const def = `#macro("card", title, body = ""):#(title)#!macro`
+
+	testCases := []struct {
+		name     string
+		call     string
+		expected string
+	}{
+		{"missing", `#macro("card")`, `missing argument for parameter title of macro "card"`},
+		{"unknown", `#macro("card", "a", titel = "b")`, `macro "card" has no parameter named titel`},
+		{"tooMany", `#macro("card", "a", "b", "c")`, `too many arguments for macro "card" (expected at most 2)`},
+		{"duplicate", `#macro("card", "a", title = "b")`, `multiple values for parameter title of macro "card"`},
+		{"order", `#macro("card", title = "a", "b")`, "positional arguments cannot follow named arguments"},
+		{"undeclared", `#macro("other"):#!macro#macro("other", "a")`, `macro "other" doesn't declare any parameters`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := New().ParseString("test", def+tc.call)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tmpl.Execute(io.Discard)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestMacroFunc(t *testing.T) {
+	tmpl, err := New().WithEscapeHTML(true).ParseString("test", `#macro("bold", text):<b>#(text)</b>#!macro`+
+		`#(bold("a & b")) #(macro("bold", text = "c")) #(big ? bold("d") : "e") #(len(bold("f")))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.WithVarMap(map[string]any{"big": true}).Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "<b>a &amp; b</b> <b>c</b> <b>d</b> 8"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestMacroFuncVarPrecedence(t *testing.T) {
+	fn := func() string { return "func" }
+
+	res := execStr(t, `#macro("x"):macro#!macro#(x())`, map[string]any{"x": fn})
+	if res != "func" {
+		t.Errorf("Expected %q, got %q", "func", res)
+	}
+}
+
+func TestMacroFuncUndefinedVarHandler(t *testing.T) {
+	var called []string
+	handler := func(name string, pos ast.Position) (any, bool, error) {
+		called = append(called, name)
+		return "", true, nil
+	}
+
+	tmpl, err := New().WithUndefinedVarHandler(handler).ParseString("test", `#macro("m"):hi#!macro#(m()) #(macro("m"))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != "hi hi" {
+		t.Errorf("Expected %q, got %q", "hi hi", sb.String())
+	}
+
+	if len(called) != 0 {
+		t.Errorf("Expected handler not to be called, got calls for %v", called)
+	}
+}
+
+func TestMacroFuncError(t *testing.T) {
+	tmpl, err := New().ParseString("test", "#macro(\"m\"):\n#(y.Z)#!macro\n#(m())")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	const expected = "test: line 3, col 3: m() ->\ntest: line 2, col 3: no such variable: y"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error containing %q, got %q", expected, err)
+	}
+}
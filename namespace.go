@@ -3,15 +3,29 @@ package salix
 import (
 	"fmt"
 	"io"
+	"maps"
 	"sync"
+	"sync/atomic"
+
+	"go.elara.ws/salix/ast"
 )
 
+// UndefinedVarFunc is called with the name and position of variables that couldn't be found.
+// If it returns true, the returned value is used as the variable's value. If it returns false,
+// a "no such variable" error is returned. A non-nil error is returned to the template as-is,
+// with the position prepended.
+type UndefinedVarFunc func(name string, pos ast.Position) (any, bool, error)
+
 // Namespace represents a collection of templates that can include each other
 type Namespace struct {
+	// mu is only held while modifying the namespace. The template, variable,
+	// and tag maps are immutable snapshots that get replaced atomically whenever
+	// they change, so templates can read them concurrently without locking.
 	mu    sync.Mutex
-	tmpls map[string]Template
-	vars  map[string]any
-	tags  map[string]Tag
+	tmpls atomic.Pointer[map[string]Template]
+	vars  atomic.Pointer[map[string]any]
+	tags  atomic.Pointer[map[string]Tag]
+	types typeCache
 
 	// WhitespaceMutations enables postprocessing to remove whitespace where it isn't needed
 	// to make the resulting document look better. Postprocessing is only done once when the
@@ -23,53 +37,73 @@ type Namespace struct {
 	// NilToZero indictes whether nil pointer values should be converted to zero values of their underlying
 	// types.
 	NilToZero bool
-	escapeHTML     *bool
+	// Strict enables strict mode, which reports errors ignored using #?() and nil values converted
+	// by NilToZero as warnings, and rejects variables that shadow global functions. (default: false)
+	Strict bool
+	// WarningHandler is called with every warning emitted in strict mode. If it's nil,
+	// warnings are written to the standard logger.
+	WarningHandler func(w *Error)
+	// Truthiness enables truthiness mode, in which non-boolean values can be used as conditions
+	// in if tags, ternary expressions, and logical operators. Empty strings, zero numbers, nil values,
+	// and empty slices and maps are false, and all other values are true, unless they implement the
+	// Truthy interface. (default: false)
+	Truthiness bool
+	// MaxWhileIterations is the maximum amount of iterations a #while tag can execute before
+	// returning an error, which prevents infinite loops. If it's zero or negative, there's no
+	// limit. (default: DefaultMaxWhileIterations)
+	MaxWhileIterations int
+	// MapOrder determines the order in which #for tags iterate over maps. (default: MapOrderSorted)
+	MapOrder MapOrder
+	// UndefinedVarHandler is called when a template references a variable that doesn't exist
+	// in any of the variable maps. See UndefinedVarFunc for more information.
+	UndefinedVarHandler UndefinedVarFunc
+	escapeHTML          atomic.Pointer[bool]
 }
 
 // New returns a new template namespace
 func New() *Namespace {
-	return &Namespace{
-		tmpls:               map[string]Template{},
-		vars:                map[string]any{},
-		tags:                map[string]Tag{},
+	n := &Namespace{
 		WhitespaceMutations: true,
 		WriteOnSuccess:      false,
+		MaxWhileIterations:  DefaultMaxWhileIterations,
 	}
+	n.tmpls.Store(&map[string]Template{})
+	n.vars.Store(&map[string]any{})
+	n.tags.Store(&map[string]Tag{})
+	return n
 }
 
-// WithVarMap sets the namespace's variable map to m
+// WithVarMap sets the namespace's variable map to m.
+// The map must not be modified after it's been passed to WithVarMap.
 func (n *Namespace) WithVarMap(m map[string]any) *Namespace {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
 	if m == nil {
-		n.vars = map[string]any{}
-	} else {
-		n.vars = m
+		m = map[string]any{}
 	}
+	n.vars.Store(&m)
 
 	return n
 }
 
-// WithTagMap sets the namespace's tag map to m
+// WithTagMap sets the namespace's tag map to m.
+// The map must not be modified after it's been passed to WithTagMap.
 func (n *Namespace) WithTagMap(m map[string]Tag) *Namespace {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
 	if m == nil {
-		n.tags = map[string]Tag{}
-	} else {
-		n.tags = m
+		m = map[string]Tag{}
 	}
+	n.tags.Store(&m)
 
 	return n
 }
 
 // WithEscapeHTML turns HTML escaping on or off for the namespace
 func (n *Namespace) WithEscapeHTML(b bool) *Namespace {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	n.escapeHTML = &b
+	n.escapeHTML.Store(&b)
 	return n
 }
 
@@ -97,13 +131,65 @@ func (n *Namespace) WithNilToZero(b bool) *Namespace {
 	return n
 }
 
+// WithStrict enables or disables strict mode for the namespace
+func (n *Namespace) WithStrict(b bool) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.Strict = b
+	return n
+}
+
+// WithWarningHandler sets the function that will be called with warnings emitted in strict mode
+func (n *Namespace) WithWarningHandler(fn func(w *Error)) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.WarningHandler = fn
+	return n
+}
+
+// WithTruthiness enables or disables truthiness mode for the namespace
+func (n *Namespace) WithTruthiness(b bool) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.Truthiness = b
+	return n
+}
+
+// WithMapOrder sets the order in which #for tags iterate over maps for the namespace
+func (n *Namespace) WithMapOrder(order MapOrder) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.MapOrder = order
+	return n
+}
+
+// WithMaxWhileIterations sets the maximum amount of iterations a #while tag can execute
+// for the namespace. If limit is zero or negative, there's no limit.
+func (n *Namespace) WithMaxWhileIterations(limit int) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.MaxWhileIterations = limit
+	return n
+}
+
+// WithUndefinedVarHandler sets the function that will be called when a template
+// references a variable that doesn't exist.
+func (n *Namespace) WithUndefinedVarHandler(fn UndefinedVarFunc) *Namespace {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.UndefinedVarHandler = fn
+	return n
+}
+
 // GetTemplate tries to get a template from the namespace's template map.
 // If it finds the template, it returns the template and true. If it
 // doesn't find it, it returns nil and false.
 func (n *Namespace) GetTemplate(name string) (Template, bool) {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	t, ok := n.tmpls[name]
+	tmpls := n.tmpls.Load()
+	if tmpls == nil {
+		return Template{}, false
+	}
+	t, ok := (*tmpls)[name]
 	return t, ok
 }
 
@@ -126,25 +212,53 @@ func (n *Namespace) ExecuteTemplate(w io.Writer, name string, vars map[string]an
 	return tmpl.WithVarMap(vars).Execute(w)
 }
 
-// getVar tries to get a variable from the namespace's variable map
-func (n *Namespace) getVar(name string) (any, bool) {
+// addTemplates adds tmpls to the namespace's template map. Since the map
+// is immutable, this copies it, which takes time proportional to the number
+// of templates in the namespace, so it's only meant to be used while parsing,
+// and templates parsed together should be added in a single call.
+func (n *Namespace) addTemplates(tmpls ...Template) {
+	if len(tmpls) == 0 {
+		return
+	}
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
-	v, ok := n.vars[name]
+
+	newTmpls := map[string]Template{}
+	if old := n.tmpls.Load(); old != nil {
+		newTmpls = maps.Clone(*old)
+	}
+	for _, t := range tmpls {
+		newTmpls[t.name] = t
+	}
+	n.tmpls.Store(&newTmpls)
+}
+
+// getVars returns the namespace's variable map
+func (n *Namespace) getVars() map[string]any {
+	if vars := n.vars.Load(); vars != nil {
+		return *vars
+	}
+	return nil
+}
+
+// getVar tries to get a variable from the namespace's variable map
+func (n *Namespace) getVar(name string) (any, bool) {
+	v, ok := n.getVars()[name]
 	return v, ok
 }
 
 // getTag tries to get a tag from the namespace's tag map
 func (n *Namespace) getTag(name string) (Tag, bool) {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	t, ok := n.tags[name]
+	tags := n.tags.Load()
+	if tags == nil {
+		return nil, false
+	}
+	t, ok := (*tags)[name]
 	return t, ok
 }
 
 // getEscapeHTML returns the namespace's escapeHTML value
 func (n *Namespace) getEscapeHTML() *bool {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	return n.escapeHTML
+	return n.escapeHTML.Load()
 }
@@ -0,0 +1,118 @@
+package salix
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go.elara.ws/salix/ast"
+)
+
+func TestUndefinedVarHandler(t *testing.T) {
+	var names []string
+	ns := New().WithUndefinedVarHandler(func(name string, pos ast.Position) (any, bool, error) {
+		names = append(names, name)
+		if name == "currentUser" {
+			return "admin", true, nil
+		}
+		return nil, false, nil
+	})
+
+	tmpl, err := ns.ParseString("test", `#(currentUser) #(title | "Home")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != "admin Home" {
+		t.Errorf("Expected %q, got %q", "admin Home", sb.String())
+	}
+
+	if len(names) != 2 || names[0] != "currentUser" || names[1] != "title" {
+		t.Errorf("Unexpected handler calls: %v", names)
+	}
+}
+
+func TestUndefinedVarHandlerError(t *testing.T) {
+	errTest := errors.New("test error")
+	ns := New().WithUndefinedVarHandler(func(name string, pos ast.Position) (any, bool, error) {
+		return nil, false, errTest
+	})
+
+	tmpl, err := ns.ParseString("test", `#(x)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if !errors.Is(err, errTest) {
+		t.Errorf("Expected error to wrap %q, got %v", errTest, err)
+	}
+}
+
+func TestParseFSGlob(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.html": {Data: []byte(`a#include("b.html")`)},
+		"b.html": {Data: []byte(`b`)},
+		"c.txt":  {Data: []byte(`c`)},
+	}
+
+	ns := New()
+	if err := ns.ParseFSGlob(fsys, "*.html"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := ns.GetTemplate("c.txt"); ok {
+		t.Error("Expected c.txt not to be parsed")
+	}
+
+	sb := strings.Builder{}
+	if err := ns.ExecuteTemplate(&sb, "a.html", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if sb.String() != "ab" {
+		t.Errorf("Expected %q, got %q", "ab", sb.String())
+	}
+}
+
+// BenchmarkParallelExecute executes a template that looks up namespace
+// variables and tags in a loop from many goroutines at once. Run it with
+// -cpu 1,2,4,8 to check that throughput scales with GOMAXPROCS.
+func BenchmarkParallelExecute(b *testing.B) {
+	ns := New().WithVarMap(map[string]any{
+		"items":  make([]int, 100),
+		"suffix": "!",
+	})
+
+	tmpl, err := ns.ParseString("bench", `#for(i, item in items):#if(i > item):#(toUpper("x") + suffix)#!if#!for`)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			err := tmpl.Execute(io.Discard)
+			if err != nil {
+				b.Error(err)
+			}
+		}
+	})
+}
+
+// BenchmarkParallelGetVar looks up a namespace variable from many goroutines at once.
+func BenchmarkParallelGetVar(b *testing.B) {
+	ns := New().WithVarMap(map[string]any{"x": 1})
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ns.getVar("x")
+		}
+	})
+}
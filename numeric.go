@@ -0,0 +1,250 @@
+package salix
+
+import (
+	"cmp"
+	"math"
+	"math/bits"
+	"reflect"
+
+	"go.elara.ws/salix/ast"
+)
+
+// numClass represents the class of a numeric value. It's used to decide
+// how the operands of an arithmetic or comparison operator are promoted.
+type numClass uint8
+
+const (
+	notNumeric numClass = iota
+	signedNum
+	unsignedNum
+	floatNum
+)
+
+// classifyNum returns the numeric class of v
+func classifyNum(v reflect.Value) numClass {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return signedNum
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return unsignedNum
+	case reflect.Float32, reflect.Float64:
+		return floatNum
+	default:
+		return notNumeric
+	}
+}
+
+// performNumericOp performs an operation on two numeric values of any kind.
+// The operands are promoted using the following rules:
+//
+//   - If either operand is a float, both are converted to float64.
+//   - If both operands are unsigned, both are converted to uint64.
+//   - If one operand is unsigned and the other is a non-negative signed
+//     value, such as an integer literal, both are converted to uint64.
+//   - Otherwise, both are converted to int64. If an unsigned operand
+//     is too large to fit in an int64, an error is returned.
+//
+// Comparisons between signed and unsigned values are always exact. Integer
+// operations that overflow, as well as division or modulus by zero, return
+// an error rather than wrapping around or panicking.
+func performNumericOp(op ast.Operator, a, b reflect.Value) (any, error) {
+	ca, cb := classifyNum(a), classifyNum(b)
+
+	switch op.Value {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if ca == floatNum || cb == floatNum {
+			x, y := toFloat64(a), toFloat64(b)
+			if math.IsNaN(x) || math.IsNaN(y) {
+				// NaN isn't equal to, less than, or greater than anything
+				return op.Value == "!=", nil
+			}
+			return compareResult(op.Value, cmp.Compare(x, y)), nil
+		}
+		return compareResult(op.Value, compareInts(a, ca, b, cb)), nil
+	}
+
+	switch {
+	case ca == floatNum || cb == floatNum:
+		return floatOp(op, toFloat64(a), toFloat64(b))
+	case ca == unsignedNum && cb == unsignedNum:
+		return uintOp(op, a.Uint(), b.Uint())
+	case ca == unsignedNum && b.Int() >= 0:
+		return uintOp(op, a.Uint(), uint64(b.Int()))
+	case cb == unsignedNum && a.Int() >= 0:
+		return uintOp(op, uint64(a.Int()), b.Uint())
+	default:
+		x, err := toInt64(op, a)
+		if err != nil {
+			return nil, err
+		}
+		y, err := toInt64(op, b)
+		if err != nil {
+			return nil, err
+		}
+		return intOp(op, x, y)
+	}
+}
+
+// compareResult converts the result of a three-way comparison
+// into the result of the given comparison operator.
+func compareResult(op string, c int) bool {
+	switch op {
+	case "==":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	default:
+		return false
+	}
+}
+
+// compareInts compares two integer values exactly,
+// even if one of them is signed and the other is unsigned.
+func compareInts(a reflect.Value, ca numClass, b reflect.Value, cb numClass) int {
+	switch {
+	case ca == signedNum && cb == signedNum:
+		return cmp.Compare(a.Int(), b.Int())
+	case ca == unsignedNum && cb == unsignedNum:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case ca == signedNum:
+		if a.Int() < 0 {
+			return -1
+		}
+		return cmp.Compare(uint64(a.Int()), b.Uint())
+	default:
+		if b.Int() < 0 {
+			return 1
+		}
+		return cmp.Compare(a.Uint(), uint64(b.Int()))
+	}
+}
+
+func toFloat64(v reflect.Value) float64 {
+	switch classifyNum(v) {
+	case signedNum:
+		return float64(v.Int())
+	case unsignedNum:
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
+
+func toInt64(op ast.Operator, v reflect.Value) (int64, error) {
+	if classifyNum(v) == signedNum {
+		return v.Int(), nil
+	}
+	u := v.Uint()
+	if u > math.MaxInt64 {
+		return 0, ast.PosError(op, "value %d overflows int64", u)
+	}
+	return int64(u), nil
+}
+
+func intOp(op ast.Operator, x, y int64) (any, error) {
+	var out int64
+	switch op.Value {
+	case "+":
+		out = x + y
+		if (y > 0 && out < x) || (y < 0 && out > x) {
+			return nil, overflowError(op, x, y)
+		}
+	case "-":
+		out = x - y
+		if (y > 0 && out > x) || (y < 0 && out < x) {
+			return nil, overflowError(op, x, y)
+		}
+	case "*":
+		if x == 0 || y == 0 {
+			return int64(0), nil
+		}
+		out = x * y
+		if out/y != x || (x == -1 && y == math.MinInt64) || (y == -1 && x == math.MinInt64) {
+			return nil, overflowError(op, x, y)
+		}
+	case "/":
+		if y == 0 {
+			return nil, ast.PosError(op, "division by zero")
+		} else if x == math.MinInt64 && y == -1 {
+			return nil, overflowError(op, x, y)
+		}
+		out = x / y
+	case "%":
+		if y == 0 {
+			return nil, ast.PosError(op, "division by zero")
+		}
+		out = x % y
+	default:
+		return nil, ast.PosError(op, "unknown operator: %q", op.Value)
+	}
+	return out, nil
+}
+
+func uintOp(op ast.Operator, x, y uint64) (any, error) {
+	var out uint64
+	switch op.Value {
+	case "+":
+		var carry uint64
+		out, carry = bits.Add64(x, y, 0)
+		if carry != 0 {
+			return nil, overflowError(op, x, y)
+		}
+	case "-":
+		var borrow uint64
+		out, borrow = bits.Sub64(x, y, 0)
+		if borrow != 0 {
+			return nil, overflowError(op, x, y)
+		}
+	case "*":
+		var hi uint64
+		hi, out = bits.Mul64(x, y)
+		if hi != 0 {
+			return nil, overflowError(op, x, y)
+		}
+	case "/":
+		if y == 0 {
+			return nil, ast.PosError(op, "division by zero")
+		}
+		out = x / y
+	case "%":
+		if y == 0 {
+			return nil, ast.PosError(op, "division by zero")
+		}
+		out = x % y
+	default:
+		return nil, ast.PosError(op, "unknown operator: %q", op.Value)
+	}
+	return out, nil
+}
+
+func floatOp(op ast.Operator, x, y float64) (any, error) {
+	switch op.Value {
+	case "+":
+		return x + y, nil
+	case "-":
+		return x - y, nil
+	case "*":
+		return x * y, nil
+	case "/":
+		if y == 0 {
+			return nil, ast.PosError(op, "division by zero")
+		}
+		return x / y, nil
+	case "%":
+		return nil, ast.PosError(op, "modulus operation cannot be performed on floats")
+	default:
+		return nil, ast.PosError(op, "unknown operator: %q", op.Value)
+	}
+}
+
+func overflowError[T int64 | uint64](op ast.Operator, x, y T) error {
+	return ast.PosError(op, "integer overflow: %d %s %d", x, op.Value, y)
+}
@@ -0,0 +1,136 @@
+package salix
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"go.elara.ws/salix/ast"
+)
+
+// evalStr parses and evaluates a single expression tag, returning its value
+func evalStr(t *testing.T, exprStr string, vars map[string]any) (any, error) {
+	t.Helper()
+	tmpl, err := New().ParseString("test", "#("+exprStr+")")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpl.getValue(tmpl.ast[0].(ast.ExprTag).Value, newScope(nil, vars))
+}
+
+func TestNumericPromotionMatrix(t *testing.T) {
+	six := []any{
+		int(6), int8(6), int16(6), int32(6), int64(6),
+		uint(6), uint8(6), uint16(6), uint32(6), uint64(6), uintptr(6),
+		float32(6), float64(6),
+	}
+	four := []any{
+		int(4), int8(4), int16(4), int32(4), int64(4),
+		uint(4), uint8(4), uint16(4), uint32(4), uint64(4), uintptr(4),
+		float32(4), float64(4),
+	}
+
+	for _, a := range six {
+		for _, b := range four {
+			ca := classifyNum(reflect.ValueOf(a))
+			cb := classifyNum(reflect.ValueOf(b))
+
+			var expected map[string]any
+			switch {
+			case ca == floatNum || cb == floatNum:
+				expected = map[string]any{"+": 10.0, "-": 2.0, "*": 24.0, "/": 1.5}
+			case ca == unsignedNum || cb == unsignedNum:
+				// All the operands are non-negative, so mixed
+				// signed and unsigned operands are promoted to uint64
+				expected = map[string]any{"+": uint64(10), "-": uint64(2), "*": uint64(24), "/": uint64(1), "%": uint64(2)}
+			default:
+				expected = map[string]any{"+": int64(10), "-": int64(2), "*": int64(24), "/": int64(1), "%": int64(2)}
+			}
+			expected["<"] = false
+			expected[">"] = true
+			expected["=="] = false
+			expected[">="] = true
+
+			for op, exp := range expected {
+				name := fmt.Sprintf("%T %s %T", a, op, b)
+				t.Run(name, func(t *testing.T) {
+					val, err := evalStr(t, "a "+op+" b", map[string]any{"a": a, "b": b})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if val != exp {
+						t.Errorf("Expected %v (%T), got %v (%T)", exp, exp, val, val)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestNumericMixedLiterals(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected any
+	}{
+		{"1 + 1.5", 2.5},
+		{"1.5 + 1", 2.5},
+		{"x / 2", 0.5},
+		{"2 / x", 2.0},
+		{"1 < 1.5", true},
+		{"1 == 1.0", true},
+		{"n < u", true},
+		{"u > n", true},
+		{"u + n", int64(0)},
+		{"u + 1", uint64(2)},
+		{"big - 1", uint64(math.MaxInt64)},
+		{"1 + big", uint64(1<<63 + 1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			val, err := evalStr(t, tc.expr, map[string]any{"x": 1.0, "n": -1, "u": uint(1), "big": uint64(1) << 63})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if val != tc.expected {
+				t.Errorf("Expected %v (%T), got %v (%T)", tc.expected, tc.expected, val, val)
+			}
+		})
+	}
+}
+
+func TestNumericErrors(t *testing.T) {
+	testCases := []struct {
+		expr string
+		vars map[string]any
+	}{
+		{"1 / 0", nil},
+		{"1 % 0", nil},
+		{"1.0 / 0", nil},
+		{"u / z", map[string]any{"u": uint(1), "z": uint(0)}},
+		{"x + 1", map[string]any{"x": int64(math.MaxInt64)}},
+		{"x - 1", map[string]any{"x": int64(math.MinInt64)}},
+		{"x * 2", map[string]any{"x": int64(math.MaxInt64)}},
+		{"x / -1", map[string]any{"x": int64(math.MinInt64)}},
+		{"a - b", map[string]any{"a": uint(1), "b": uint(2)}},
+		{"a + b", map[string]any{"a": uint64(math.MaxUint64), "b": uint(1)}},
+		{"a + 1", map[string]any{"a": uint64(math.MaxUint64)}},
+		{"1.5 % 1", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := evalStr(t, tc.expr, tc.vars)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			var nerr *ast.NodeError
+			if !errors.As(err, &nerr) {
+				t.Errorf("Expected error with position, got %T", err)
+			}
+		})
+	}
+}
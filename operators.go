@@ -0,0 +1,105 @@
+package salix
+
+import (
+	"reflect"
+
+	"go.elara.ws/salix/ast"
+)
+
+// Adder can be implemented by types that support the + operator.
+// Operator methods are only called on the left operand.
+type Adder interface {
+	Add(other any) (any, error)
+}
+
+// Subtractor can be implemented by types that support the - operator.
+// Operator methods are only called on the left operand.
+type Subtractor interface {
+	Sub(other any) (any, error)
+}
+
+// Multiplier can be implemented by types that support the * operator.
+// Operator methods are only called on the left operand.
+type Multiplier interface {
+	Mul(other any) (any, error)
+}
+
+// Divider can be implemented by types that support the / operator.
+// Operator methods are only called on the left operand.
+type Divider interface {
+	Div(other any) (any, error)
+}
+
+// Modder can be implemented by types that support the % operator.
+// Operator methods are only called on the left operand.
+type Modder interface {
+	Mod(other any) (any, error)
+}
+
+// Equaler can be implemented by types that support the == and != operators.
+// Unlike the other operator interfaces, Equal is called on whichever operand
+// implements it, with the left operand taking precedence.
+type Equaler interface {
+	Equal(other any) bool
+}
+
+// performOverloadedOp performs an operation using the operator interfaces
+// implemented by a or b. If they don't implement the interface for op,
+// it returns false.
+func performOverloadedOp(op ast.Operator, a, b reflect.Value) (any, bool, error) {
+	x, y := a.Interface(), b.Interface()
+
+	var (
+		res any
+		err error
+	)
+	switch op.Value {
+	case "+":
+		v, ok := x.(Adder)
+		if !ok {
+			return nil, false, nil
+		}
+		res, err = v.Add(y)
+	case "-":
+		v, ok := x.(Subtractor)
+		if !ok {
+			return nil, false, nil
+		}
+		res, err = v.Sub(y)
+	case "*":
+		v, ok := x.(Multiplier)
+		if !ok {
+			return nil, false, nil
+		}
+		res, err = v.Mul(y)
+	case "/":
+		v, ok := x.(Divider)
+		if !ok {
+			return nil, false, nil
+		}
+		res, err = v.Div(y)
+	case "%":
+		v, ok := x.(Modder)
+		if !ok {
+			return nil, false, nil
+		}
+		res, err = v.Mod(y)
+	case "==", "!=":
+		var eq bool
+		if v, ok := x.(Equaler); ok {
+			eq = v.Equal(y)
+		} else if v, ok := y.(Equaler); ok {
+			eq = v.Equal(x)
+		} else {
+			return nil, false, nil
+		}
+		return eq == (op.Value == "=="), true, nil
+	default:
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, true, ast.PosError(op, "%w", err)
+	}
+	return res, true, nil
+}
@@ -0,0 +1,105 @@
+package salix
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+type money struct {
+	cents int64
+}
+
+func (m money) Add(other any) (any, error) {
+	switch other := other.(type) {
+	case money:
+		return money{m.cents + other.cents}, nil
+	case int64:
+		return money{m.cents + other*100}, nil
+	default:
+		return nil, fmt.Errorf("cannot add %T to money", other)
+	}
+}
+
+func (m money) Sub(other any) (any, error) {
+	o, ok := other.(money)
+	if !ok {
+		return nil, fmt.Errorf("cannot subtract %T from money", other)
+	}
+	return money{m.cents - o.cents}, nil
+}
+
+func (m money) Equal(other any) bool {
+	o, ok := other.(money)
+	return ok && m.cents == o.cents
+}
+
+func (m money) Compare(other any) int {
+	return int(m.cents - other.(money).cents)
+}
+
+func (m money) String() string {
+	return fmt.Sprintf("$%d.%02d", m.cents/100, m.cents%100)
+}
+
+type bigInt struct {
+	*big.Int
+}
+
+func (bi bigInt) Mul(other any) (any, error) {
+	o, ok := other.(bigInt)
+	if !ok {
+		return nil, errors.New("expected bigInt")
+	}
+	return bigInt{new(big.Int).Mul(bi.Int, o.Int)}, nil
+}
+
+func (bi bigInt) Equal(other any) bool {
+	o, ok := other.(bigInt)
+	return ok && bi.Cmp(o.Int) == 0
+}
+
+func TestOperatorOverloading(t *testing.T) {
+	big1, _ := new(big.Int).SetString("100000000000000000000", 10)
+	big2, _ := new(big.Int).SetString("10000000000000000000000000000000000000000", 10)
+
+	vars := map[string]any{
+		"price":    money{1050},
+		"shipping": money{499},
+		"total":    money{1549},
+		"big1":     bigInt{big1},
+		"big2":     bigInt{big2},
+	}
+
+	testCases := []struct {
+		expr     string
+		expected string
+	}{
+		{`price + shipping`, "$15.49"},
+		{`price + 2`, "$12.50"},
+		{`total - shipping`, "$10.50"},
+		{`price + shipping == total`, "true"},
+		{`price != total`, "true"},
+		{`price < total`, "true"},
+		{`price >= total`, "false"},
+		{`big1 * big1`, big2.String()},
+		{`big1 * big1 == big2`, "true"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			res := execStr(t, "#("+tc.expr+")", vars)
+			if res != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestOperatorOverloadingError(t *testing.T) {
+	_, err := evalStr(t, `price + "x"`, map[string]any{"price": money{100}})
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
@@ -26,7 +26,23 @@ func (n *Namespace) Parse(r NamedReader) (Template, error) {
 
 // ParseWithFilename parses a salix template from r, using the given name.
 func (n *Namespace) ParseWithName(name string, r io.Reader) (Template, error) {
-	astVal, err := parser.ParseReader(name, r, parser.GlobalStore("name", name))
+	t, err := n.parse(name, r)
+	if err != nil {
+		return Template{}, err
+	}
+	n.addTemplates(t)
+	return t, nil
+}
+
+// parse parses a salix template from r, using the given name,
+// without adding it to the namespace.
+func (n *Namespace) parse(name string, r io.Reader) (Template, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return Template{}, err
+	}
+
+	astVal, err := parser.Parse(name, src, parser.GlobalStore("name", name))
 	if err != nil {
 		return Template{}, err
 	}
@@ -34,6 +50,7 @@ func (n *Namespace) ParseWithName(name string, r io.Reader) (Template, error) {
 	t := Template{
 		ns:             n,
 		name:           name,
+		src:            src,
 		ast:            astVal.([]ast.Node),
 		tags:           map[string]Tag{},
 		vars:           map[string]any{},
@@ -44,12 +61,34 @@ func (n *Namespace) ParseWithName(name string, r io.Reader) (Template, error) {
 		performWhitespaceMutations(t.ast)
 	}
 
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	n.tmpls[name] = t
 	return t, nil
 }
 
+// parsePaths opens and parses the files at the given paths, using each path
+// as the name. The templates are added to the namespace all at once, rather than
+// one by one, to avoid copying the template map for each of them. If an error
+// occurs, the templates that were parsed before it are still added.
+func (n *Namespace) parsePaths(paths []string, open func(path string) (io.ReadCloser, error)) error {
+	tmpls := make([]Template, 0, len(paths))
+	defer func() { n.addTemplates(tmpls...) }()
+
+	for _, path := range paths {
+		fl, err := open(path)
+		if err != nil {
+			return err
+		}
+
+		t, err := n.parse(path, fl)
+		fl.Close()
+		if err != nil {
+			return err
+		}
+		tmpls = append(tmpls, t)
+	}
+
+	return nil
+}
+
 // ParseFile parses the file at path as a salix template. It uses the path as the name.
 func (t *Namespace) ParseFile(path string) (Template, error) {
 	fl, err := os.Open(path)
@@ -68,14 +107,9 @@ func (t *Namespace) ParseGlob(glob string) error {
 		return err
 	}
 
-	for _, match := range matches {
-		_, err := t.ParseFile(match)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return t.parsePaths(matches, func(path string) (io.ReadCloser, error) {
+		return os.Open(path)
+	})
 }
 
 // ParseFile parses a file at the given path in a filesystem. It uses the path as the name.
@@ -96,14 +130,9 @@ func (t *Namespace) ParseFSGlob(fsys fs.FS, glob string) error {
 		return err
 	}
 
-	for _, match := range matches {
-		_, err := t.ParseFS(fsys, match)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return t.parsePaths(matches, func(path string) (io.ReadCloser, error) {
+		return fsys.Open(path)
+	})
 }
 
 // ParseString parses a string using the given filename.
@@ -77,44 +77,48 @@ var g = &grammar{
 							alternatives: []any{
 								&ruleRefExpr{
 									pos:  position{line: 53, col: 15, offset: 1015},
+									name: "ForTag",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 53, col: 24, offset: 1024},
 									name: "Tag",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 53, col: 21, offset: 1021},
+									pos:  position{line: 53, col: 30, offset: 1030},
 									name: "ExprTag",
 								},
 								&actionExpr{
-									pos: position{line: 71, col: 10, offset: 1455},
-									run: (*parser).callonRoot7,
+									pos: position{line: 126, col: 10, offset: 2923},
+									run: (*parser).callonRoot8,
 									expr: &seqExpr{
-										pos: position{line: 71, col: 10, offset: 1455},
+										pos: position{line: 126, col: 10, offset: 2923},
 										exprs: []any{
 											&litMatcher{
-												pos:        position{line: 71, col: 10, offset: 1455},
+												pos:        position{line: 126, col: 10, offset: 2923},
 												val:        "#!",
 												ignoreCase: false,
 												want:       "\"#!\"",
 											},
 											&labeledExpr{
-												pos:   position{line: 71, col: 15, offset: 1460},
+												pos:   position{line: 126, col: 15, offset: 2928},
 												label: "name",
 												expr: &actionExpr{
-													pos: position{line: 215, col: 9, offset: 5217},
-													run: (*parser).callonRoot11,
+													pos: position{line: 270, col: 9, offset: 6685},
+													run: (*parser).callonRoot12,
 													expr: &seqExpr{
-														pos: position{line: 215, col: 9, offset: 5217},
+														pos: position{line: 270, col: 9, offset: 6685},
 														exprs: []any{
 															&charClassMatcher{
-																pos:        position{line: 215, col: 9, offset: 5217},
+																pos:        position{line: 270, col: 9, offset: 6685},
 																val:        "[a-z]i",
 																ranges:     []rune{'a', 'z'},
 																ignoreCase: true,
 																inverted:   false,
 															},
 															&zeroOrMoreExpr{
-																pos: position{line: 215, col: 16, offset: 5224},
+																pos: position{line: 270, col: 16, offset: 6692},
 																expr: &charClassMatcher{
-																	pos:        position{line: 215, col: 16, offset: 5224},
+																	pos:        position{line: 270, col: 16, offset: 6692},
 																	val:        "[_a-z0-9]i",
 																	chars:      []rune{'_'},
 																	ranges:     []rune{'a', 'z', '0', '9'},
@@ -130,18 +134,18 @@ var g = &grammar{
 									},
 								},
 								&actionExpr{
-									pos: position{line: 295, col: 8, offset: 6924},
-									run: (*parser).callonRoot16,
+									pos: position{line: 350, col: 8, offset: 8392},
+									run: (*parser).callonRoot17,
 									expr: &seqExpr{
-										pos: position{line: 295, col: 8, offset: 6924},
+										pos: position{line: 350, col: 8, offset: 8392},
 										exprs: []any{
 											&anyMatcher{
-												line: 295, col: 8, offset: 6924,
+												line: 350, col: 8, offset: 8392,
 											},
 											&zeroOrMoreExpr{
-												pos: position{line: 295, col: 10, offset: 6926},
+												pos: position{line: 350, col: 10, offset: 8394},
 												expr: &charClassMatcher{
-													pos:        position{line: 295, col: 10, offset: 6926},
+													pos:        position{line: 350, col: 10, offset: 8394},
 													val:        "[^#]",
 													chars:      []rune{'#'},
 													ignoreCase: false,
@@ -161,39 +165,39 @@ var g = &grammar{
 		},
 		{
 			name: "Tag",
-			pos:  position{line: 62, col: 1, offset: 1226},
+			pos:  position{line: 62, col: 1, offset: 1235},
 			expr: &actionExpr{
-				pos: position{line: 62, col: 7, offset: 1232},
+				pos: position{line: 62, col: 7, offset: 1241},
 				run: (*parser).callonTag1,
 				expr: &seqExpr{
-					pos: position{line: 62, col: 7, offset: 1232},
+					pos: position{line: 62, col: 7, offset: 1241},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 62, col: 7, offset: 1232},
+							pos:        position{line: 62, col: 7, offset: 1241},
 							val:        "#",
 							ignoreCase: false,
 							want:       "\"#\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 62, col: 11, offset: 1236},
+							pos:   position{line: 62, col: 11, offset: 1245},
 							label: "name",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonTag5,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -206,23 +210,330 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 62, col: 22, offset: 1247},
+							pos:   position{line: 62, col: 22, offset: 1256},
 							label: "params",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 62, col: 29, offset: 1254},
+								pos: position{line: 62, col: 29, offset: 1263},
 								expr: &ruleRefExpr{
-									pos:  position{line: 62, col: 29, offset: 1254},
+									pos:  position{line: 62, col: 29, offset: 1263},
 									name: "ParamList",
 								},
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 62, col: 40, offset: 1265},
+							pos:   position{line: 62, col: 40, offset: 1274},
+							label: "body",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 62, col: 45, offset: 1279},
+								expr: &litMatcher{
+									pos:        position{line: 62, col: 45, offset: 1279},
+									val:        ":",
+									ignoreCase: false,
+									want:       "\":\"",
+								},
+							},
+						},
+					},
+				},
+			},
+			leader:        false,
+			leftRecursive: false,
+		},
+		{
+			name: "ForTag",
+			pos:  position{line: 71, col: 1, offset: 1455},
+			expr: &actionExpr{
+				pos: position{line: 71, col: 10, offset: 1464},
+				run: (*parser).callonForTag1,
+				expr: &seqExpr{
+					pos: position{line: 71, col: 10, offset: 1464},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 71, col: 10, offset: 1464},
+							val:        "#",
+							ignoreCase: false,
+							want:       "\"#\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 71, col: 14, offset: 1468},
+							label: "name",
+							expr: &actionExpr{
+								pos: position{line: 92, col: 11, offset: 2080},
+								run: (*parser).callonForTag5,
+								expr: &litMatcher{
+									pos:        position{line: 92, col: 11, offset: 2080},
+									val:        "for",
+									ignoreCase: false,
+									want:       "\"for\"",
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 71, col: 27, offset: 1481},
+							val:        "(",
+							ignoreCase: false,
+							want:       "\"(\"",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 352, col: 18, offset: 8480},
+							expr: &charClassMatcher{
+								pos:        position{line: 352, col: 18, offset: 8480},
+								val:        "[ \\t\\r\\n]",
+								chars:      []rune{' ', '\t', '\r', '\n'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 71, col: 33, offset: 1487},
+							label: "vars",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 71, col: 38, offset: 1492},
+								expr: &seqExpr{
+									pos: position{line: 71, col: 39, offset: 1493},
+									exprs: []any{
+										&choiceExpr{
+											pos: position{line: 111, col: 10, offset: 2527},
+											alternatives: []any{
+												&actionExpr{
+													pos: position{line: 115, col: 13, offset: 2616},
+													run: (*parser).callonForTag14,
+													expr: &seqExpr{
+														pos: position{line: 115, col: 13, offset: 2616},
+														exprs: []any{
+															&litMatcher{
+																pos:        position{line: 115, col: 13, offset: 2616},
+																val:        "(",
+																ignoreCase: false,
+																want:       "\"(\"",
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 352, col: 18, offset: 8480},
+																expr: &charClassMatcher{
+																	pos:        position{line: 352, col: 18, offset: 8480},
+																	val:        "[ \\t\\r\\n]",
+																	chars:      []rune{' ', '\t', '\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   false,
+																},
+															},
+															&labeledExpr{
+																pos:   position{line: 115, col: 19, offset: 2622},
+																label: "first",
+																expr: &actionExpr{
+																	pos: position{line: 270, col: 9, offset: 6685},
+																	run: (*parser).callonForTag20,
+																	expr: &seqExpr{
+																		pos: position{line: 270, col: 9, offset: 6685},
+																		exprs: []any{
+																			&charClassMatcher{
+																				pos:        position{line: 270, col: 9, offset: 6685},
+																				val:        "[a-z]i",
+																				ranges:     []rune{'a', 'z'},
+																				ignoreCase: true,
+																				inverted:   false,
+																			},
+																			&zeroOrMoreExpr{
+																				pos: position{line: 270, col: 16, offset: 6692},
+																				expr: &charClassMatcher{
+																					pos:        position{line: 270, col: 16, offset: 6692},
+																					val:        "[_a-z0-9]i",
+																					chars:      []rune{'_'},
+																					ranges:     []rune{'a', 'z', '0', '9'},
+																					ignoreCase: true,
+																					inverted:   false,
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															&labeledExpr{
+																pos:   position{line: 115, col: 31, offset: 2634},
+																label: "rest",
+																expr: &zeroOrMoreExpr{
+																	pos: position{line: 115, col: 36, offset: 2639},
+																	expr: &seqExpr{
+																		pos: position{line: 115, col: 37, offset: 2640},
+																		exprs: []any{
+																			&zeroOrMoreExpr{
+																				pos: position{line: 352, col: 18, offset: 8480},
+																				expr: &charClassMatcher{
+																					pos:        position{line: 352, col: 18, offset: 8480},
+																					val:        "[ \\t\\r\\n]",
+																					chars:      []rune{' ', '\t', '\r', '\n'},
+																					ignoreCase: false,
+																					inverted:   false,
+																				},
+																			},
+																			&litMatcher{
+																				pos:        position{line: 115, col: 39, offset: 2642},
+																				val:        ",",
+																				ignoreCase: false,
+																				want:       "\",\"",
+																			},
+																			&zeroOrMoreExpr{
+																				pos: position{line: 352, col: 18, offset: 8480},
+																				expr: &charClassMatcher{
+																					pos:        position{line: 352, col: 18, offset: 8480},
+																					val:        "[ \\t\\r\\n]",
+																					chars:      []rune{' ', '\t', '\r', '\n'},
+																					ignoreCase: false,
+																					inverted:   false,
+																				},
+																			},
+																			&actionExpr{
+																				pos: position{line: 270, col: 9, offset: 6685},
+																				run: (*parser).callonForTag33,
+																				expr: &seqExpr{
+																					pos: position{line: 270, col: 9, offset: 6685},
+																					exprs: []any{
+																						&charClassMatcher{
+																							pos:        position{line: 270, col: 9, offset: 6685},
+																							val:        "[a-z]i",
+																							ranges:     []rune{'a', 'z'},
+																							ignoreCase: true,
+																							inverted:   false,
+																						},
+																						&zeroOrMoreExpr{
+																							pos: position{line: 270, col: 16, offset: 6692},
+																							expr: &charClassMatcher{
+																								pos:        position{line: 270, col: 16, offset: 6692},
+																								val:        "[_a-z0-9]i",
+																								chars:      []rune{'_'},
+																								ranges:     []rune{'a', 'z', '0', '9'},
+																								ignoreCase: true,
+																								inverted:   false,
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															&zeroOrMoreExpr{
+																pos: position{line: 352, col: 18, offset: 8480},
+																expr: &charClassMatcher{
+																	pos:        position{line: 352, col: 18, offset: 8480},
+																	val:        "[ \\t\\r\\n]",
+																	chars:      []rune{' ', '\t', '\r', '\n'},
+																	ignoreCase: false,
+																	inverted:   false,
+																},
+															},
+															&litMatcher{
+																pos:        position{line: 115, col: 55, offset: 2658},
+																val:        ")",
+																ignoreCase: false,
+																want:       "\")\"",
+															},
+														},
+													},
+												},
+												&actionExpr{
+													pos: position{line: 111, col: 22, offset: 2539},
+													run: (*parser).callonForTag41,
+													expr: &labeledExpr{
+														pos:   position{line: 111, col: 22, offset: 2539},
+														label: "name",
+														expr: &actionExpr{
+															pos: position{line: 270, col: 9, offset: 6685},
+															run: (*parser).callonForTag43,
+															expr: &seqExpr{
+																pos: position{line: 270, col: 9, offset: 6685},
+																exprs: []any{
+																	&charClassMatcher{
+																		pos:        position{line: 270, col: 9, offset: 6685},
+																		val:        "[a-z]i",
+																		ranges:     []rune{'a', 'z'},
+																		ignoreCase: true,
+																		inverted:   false,
+																	},
+																	&zeroOrMoreExpr{
+																		pos: position{line: 270, col: 16, offset: 6692},
+																		expr: &charClassMatcher{
+																			pos:        position{line: 270, col: 16, offset: 6692},
+																			val:        "[_a-z0-9]i",
+																			chars:      []rune{'_'},
+																			ranges:     []rune{'a', 'z', '0', '9'},
+																			ignoreCase: true,
+																			inverted:   false,
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 352, col: 18, offset: 8480},
+											expr: &charClassMatcher{
+												pos:        position{line: 352, col: 18, offset: 8480},
+												val:        "[ \\t\\r\\n]",
+												chars:      []rune{' ', '\t', '\r', '\n'},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+										&litMatcher{
+											pos:        position{line: 71, col: 48, offset: 1502},
+											val:        ",",
+											ignoreCase: false,
+											want:       "\",\"",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 352, col: 18, offset: 8480},
+											expr: &charClassMatcher{
+												pos:        position{line: 352, col: 18, offset: 8480},
+												val:        "[ \\t\\r\\n]",
+												chars:      []rune{' ', '\t', '\r', '\n'},
+												ignoreCase: false,
+												inverted:   false,
+											},
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 71, col: 56, offset: 1510},
+							label: "last",
+							expr: &ruleRefExpr{
+								pos:  position{line: 71, col: 61, offset: 1515},
+								name: "ForInExpr",
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 352, col: 18, offset: 8480},
+							expr: &charClassMatcher{
+								pos:        position{line: 352, col: 18, offset: 8480},
+								val:        "[ \\t\\r\\n]",
+								chars:      []rune{' ', '\t', '\r', '\n'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 71, col: 73, offset: 1527},
+							val:        ")",
+							ignoreCase: false,
+							want:       "\")\"",
+						},
+						&andCodeExpr{
+							pos: position{line: 71, col: 77, offset: 1531},
+							run: (*parser).callonForTag58,
+						},
+						&labeledExpr{
+							pos:   position{line: 79, col: 3, offset: 1743},
 							label: "body",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 62, col: 45, offset: 1270},
+								pos: position{line: 79, col: 8, offset: 1748},
 								expr: &litMatcher{
-									pos:        position{line: 62, col: 45, offset: 1270},
+									pos:        position{line: 79, col: 8, offset: 1748},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
@@ -235,28 +546,302 @@ var g = &grammar{
 			leader:        false,
 			leftRecursive: false,
 		},
+		{
+			name: "ForInExpr",
+			pos:  position{line: 99, col: 1, offset: 2187},
+			expr: &actionExpr{
+				pos: position{line: 99, col: 13, offset: 2199},
+				run: (*parser).callonForInExpr1,
+				expr: &seqExpr{
+					pos: position{line: 99, col: 13, offset: 2199},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 99, col: 13, offset: 2199},
+							label: "first",
+							expr: &choiceExpr{
+								pos: position{line: 111, col: 10, offset: 2527},
+								alternatives: []any{
+									&actionExpr{
+										pos: position{line: 115, col: 13, offset: 2616},
+										run: (*parser).callonForInExpr5,
+										expr: &seqExpr{
+											pos: position{line: 115, col: 13, offset: 2616},
+											exprs: []any{
+												&litMatcher{
+													pos:        position{line: 115, col: 13, offset: 2616},
+													val:        "(",
+													ignoreCase: false,
+													want:       "\"(\"",
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 352, col: 18, offset: 8480},
+													expr: &charClassMatcher{
+														pos:        position{line: 352, col: 18, offset: 8480},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+												},
+												&labeledExpr{
+													pos:   position{line: 115, col: 19, offset: 2622},
+													label: "first",
+													expr: &actionExpr{
+														pos: position{line: 270, col: 9, offset: 6685},
+														run: (*parser).callonForInExpr11,
+														expr: &seqExpr{
+															pos: position{line: 270, col: 9, offset: 6685},
+															exprs: []any{
+																&charClassMatcher{
+																	pos:        position{line: 270, col: 9, offset: 6685},
+																	val:        "[a-z]i",
+																	ranges:     []rune{'a', 'z'},
+																	ignoreCase: true,
+																	inverted:   false,
+																},
+																&zeroOrMoreExpr{
+																	pos: position{line: 270, col: 16, offset: 6692},
+																	expr: &charClassMatcher{
+																		pos:        position{line: 270, col: 16, offset: 6692},
+																		val:        "[_a-z0-9]i",
+																		chars:      []rune{'_'},
+																		ranges:     []rune{'a', 'z', '0', '9'},
+																		ignoreCase: true,
+																		inverted:   false,
+																	},
+																},
+															},
+														},
+													},
+												},
+												&labeledExpr{
+													pos:   position{line: 115, col: 31, offset: 2634},
+													label: "rest",
+													expr: &zeroOrMoreExpr{
+														pos: position{line: 115, col: 36, offset: 2639},
+														expr: &seqExpr{
+															pos: position{line: 115, col: 37, offset: 2640},
+															exprs: []any{
+																&zeroOrMoreExpr{
+																	pos: position{line: 352, col: 18, offset: 8480},
+																	expr: &charClassMatcher{
+																		pos:        position{line: 352, col: 18, offset: 8480},
+																		val:        "[ \\t\\r\\n]",
+																		chars:      []rune{' ', '\t', '\r', '\n'},
+																		ignoreCase: false,
+																		inverted:   false,
+																	},
+																},
+																&litMatcher{
+																	pos:        position{line: 115, col: 39, offset: 2642},
+																	val:        ",",
+																	ignoreCase: false,
+																	want:       "\",\"",
+																},
+																&zeroOrMoreExpr{
+																	pos: position{line: 352, col: 18, offset: 8480},
+																	expr: &charClassMatcher{
+																		pos:        position{line: 352, col: 18, offset: 8480},
+																		val:        "[ \\t\\r\\n]",
+																		chars:      []rune{' ', '\t', '\r', '\n'},
+																		ignoreCase: false,
+																		inverted:   false,
+																	},
+																},
+																&actionExpr{
+																	pos: position{line: 270, col: 9, offset: 6685},
+																	run: (*parser).callonForInExpr24,
+																	expr: &seqExpr{
+																		pos: position{line: 270, col: 9, offset: 6685},
+																		exprs: []any{
+																			&charClassMatcher{
+																				pos:        position{line: 270, col: 9, offset: 6685},
+																				val:        "[a-z]i",
+																				ranges:     []rune{'a', 'z'},
+																				ignoreCase: true,
+																				inverted:   false,
+																			},
+																			&zeroOrMoreExpr{
+																				pos: position{line: 270, col: 16, offset: 6692},
+																				expr: &charClassMatcher{
+																					pos:        position{line: 270, col: 16, offset: 6692},
+																					val:        "[_a-z0-9]i",
+																					chars:      []rune{'_'},
+																					ranges:     []rune{'a', 'z', '0', '9'},
+																					ignoreCase: true,
+																					inverted:   false,
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+												&zeroOrMoreExpr{
+													pos: position{line: 352, col: 18, offset: 8480},
+													expr: &charClassMatcher{
+														pos:        position{line: 352, col: 18, offset: 8480},
+														val:        "[ \\t\\r\\n]",
+														chars:      []rune{' ', '\t', '\r', '\n'},
+														ignoreCase: false,
+														inverted:   false,
+													},
+												},
+												&litMatcher{
+													pos:        position{line: 115, col: 55, offset: 2658},
+													val:        ")",
+													ignoreCase: false,
+													want:       "\")\"",
+												},
+											},
+										},
+									},
+									&actionExpr{
+										pos: position{line: 111, col: 22, offset: 2539},
+										run: (*parser).callonForInExpr32,
+										expr: &labeledExpr{
+											pos:   position{line: 111, col: 22, offset: 2539},
+											label: "name",
+											expr: &actionExpr{
+												pos: position{line: 270, col: 9, offset: 6685},
+												run: (*parser).callonForInExpr34,
+												expr: &seqExpr{
+													pos: position{line: 270, col: 9, offset: 6685},
+													exprs: []any{
+														&charClassMatcher{
+															pos:        position{line: 270, col: 9, offset: 6685},
+															val:        "[a-z]i",
+															ranges:     []rune{'a', 'z'},
+															ignoreCase: true,
+															inverted:   false,
+														},
+														&zeroOrMoreExpr{
+															pos: position{line: 270, col: 16, offset: 6692},
+															expr: &charClassMatcher{
+																pos:        position{line: 270, col: 16, offset: 6692},
+																val:        "[_a-z0-9]i",
+																chars:      []rune{'_'},
+																ranges:     []rune{'a', 'z', '0', '9'},
+																ignoreCase: true,
+																inverted:   false,
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 352, col: 18, offset: 8480},
+							expr: &charClassMatcher{
+								pos:        position{line: 352, col: 18, offset: 8480},
+								val:        "[ \\t\\r\\n]",
+								chars:      []rune{' ', '\t', '\r', '\n'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 99, col: 28, offset: 2214},
+							label: "op",
+							expr: &actionExpr{
+								pos: position{line: 332, col: 16, offset: 8022},
+								run: (*parser).callonForInExpr42,
+								expr: &choiceExpr{
+									pos: position{line: 332, col: 17, offset: 8023},
+									alternatives: []any{
+										&litMatcher{
+											pos:        position{line: 332, col: 17, offset: 8023},
+											val:        "==",
+											ignoreCase: false,
+											want:       "\"==\"",
+										},
+										&litMatcher{
+											pos:        position{line: 332, col: 24, offset: 8030},
+											val:        "!=",
+											ignoreCase: false,
+											want:       "\"!=\"",
+										},
+										&litMatcher{
+											pos:        position{line: 332, col: 31, offset: 8037},
+											val:        "<=",
+											ignoreCase: false,
+											want:       "\"<=\"",
+										},
+										&litMatcher{
+											pos:        position{line: 332, col: 38, offset: 8044},
+											val:        ">=",
+											ignoreCase: false,
+											want:       "\">=\"",
+										},
+										&charClassMatcher{
+											pos:        position{line: 332, col: 45, offset: 8051},
+											val:        "[<>]",
+											chars:      []rune{'<', '>'},
+											ignoreCase: false,
+											inverted:   false,
+										},
+										&litMatcher{
+											pos:        position{line: 332, col: 57, offset: 8063},
+											val:        "in",
+											ignoreCase: true,
+											want:       "\"in\"i",
+										},
+									},
+								},
+							},
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 352, col: 18, offset: 8480},
+							expr: &charClassMatcher{
+								pos:        position{line: 352, col: 18, offset: 8480},
+								val:        "[ \\t\\r\\n]",
+								chars:      []rune{' ', '\t', '\r', '\n'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 99, col: 46, offset: 2232},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 99, col: 52, offset: 2238},
+								name: "ArithmeticExpr",
+							},
+						},
+					},
+				},
+			},
+			leader:        false,
+			leftRecursive: false,
+		},
 		{
 			name: "ExprTag",
-			pos:  position{line: 78, col: 1, offset: 1575},
+			pos:  position{line: 133, col: 1, offset: 3043},
 			expr: &actionExpr{
-				pos: position{line: 78, col: 11, offset: 1585},
+				pos: position{line: 133, col: 11, offset: 3053},
 				run: (*parser).callonExprTag1,
 				expr: &seqExpr{
-					pos: position{line: 78, col: 11, offset: 1585},
+					pos: position{line: 133, col: 11, offset: 3053},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 78, col: 11, offset: 1585},
+							pos:        position{line: 133, col: 11, offset: 3053},
 							val:        "#",
 							ignoreCase: false,
 							want:       "\"#\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 78, col: 15, offset: 1589},
+							pos:   position{line: 133, col: 15, offset: 3057},
 							label: "ignoreErr",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 78, col: 25, offset: 1599},
+								pos: position{line: 133, col: 25, offset: 3067},
 								expr: &litMatcher{
-									pos:        position{line: 78, col: 25, offset: 1599},
+									pos:        position{line: 133, col: 25, offset: 3067},
 									val:        "?",
 									ignoreCase: false,
 									want:       "\"?\"",
@@ -264,21 +849,21 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 78, col: 30, offset: 1604},
+							pos:        position{line: 133, col: 30, offset: 3072},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 78, col: 34, offset: 1608},
+							pos:   position{line: 133, col: 34, offset: 3076},
 							label: "item",
 							expr: &ruleRefExpr{
-								pos:  position{line: 78, col: 39, offset: 1613},
+								pos:  position{line: 133, col: 39, offset: 3081},
 								name: "Expr",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 78, col: 44, offset: 1618},
+							pos:        position{line: 133, col: 44, offset: 3086},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -291,16 +876,16 @@ var g = &grammar{
 		},
 		{
 			name: "Expr",
-			pos:  position{line: 86, col: 1, offset: 1771},
+			pos:  position{line: 141, col: 1, offset: 3239},
 			expr: &choiceExpr{
-				pos: position{line: 86, col: 8, offset: 1778},
+				pos: position{line: 141, col: 8, offset: 3246},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 86, col: 8, offset: 1778},
+						pos:  position{line: 141, col: 8, offset: 3246},
 						name: "Assignment",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 86, col: 21, offset: 1791},
+						pos:  position{line: 141, col: 21, offset: 3259},
 						name: "TernaryExpr",
 					},
 				},
@@ -310,9 +895,9 @@ var g = &grammar{
 		},
 		{
 			name: "Assignable",
-			pos:  position{line: 87, col: 1, offset: 1803},
+			pos:  position{line: 142, col: 1, offset: 3271},
 			expr: &ruleRefExpr{
-				pos:  position{line: 87, col: 14, offset: 1816},
+				pos:  position{line: 142, col: 14, offset: 3284},
 				name: "TernaryExpr",
 			},
 			leader:        false,
@@ -320,17 +905,17 @@ var g = &grammar{
 		},
 		{
 			name: "TernaryExpr",
-			pos:  position{line: 89, col: 1, offset: 1829},
+			pos:  position{line: 144, col: 1, offset: 3297},
 			expr: &actionExpr{
-				pos: position{line: 89, col: 15, offset: 1843},
+				pos: position{line: 144, col: 15, offset: 3311},
 				run: (*parser).callonTernaryExpr1,
 				expr: &seqExpr{
-					pos: position{line: 89, col: 15, offset: 1843},
+					pos: position{line: 144, col: 15, offset: 3311},
 					exprs: []any{
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -338,25 +923,25 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 89, col: 17, offset: 1845},
+							pos:   position{line: 144, col: 17, offset: 3313},
 							label: "cond",
 							expr: &ruleRefExpr{
-								pos:  position{line: 89, col: 22, offset: 1850},
+								pos:  position{line: 144, col: 22, offset: 3318},
 								name: "LogicalExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 89, col: 34, offset: 1862},
+							pos:   position{line: 144, col: 34, offset: 3330},
 							label: "vals",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 89, col: 39, offset: 1867},
+								pos: position{line: 144, col: 39, offset: 3335},
 								expr: &seqExpr{
-									pos: position{line: 89, col: 40, offset: 1868},
+									pos: position{line: 144, col: 40, offset: 3336},
 									exprs: []any{
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -364,15 +949,15 @@ var g = &grammar{
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 89, col: 42, offset: 1870},
+											pos:        position{line: 144, col: 42, offset: 3338},
 											val:        "?",
 											ignoreCase: false,
 											want:       "\"?\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -380,13 +965,13 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 48, offset: 1876},
+											pos:  position{line: 144, col: 48, offset: 3344},
 											name: "Value",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -394,15 +979,15 @@ var g = &grammar{
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 89, col: 56, offset: 1884},
+											pos:        position{line: 144, col: 56, offset: 3352},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -410,7 +995,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 89, col: 62, offset: 1890},
+											pos:  position{line: 144, col: 62, offset: 3358},
 											name: "Value",
 										},
 									},
@@ -425,17 +1010,17 @@ var g = &grammar{
 		},
 		{
 			name: "LogicalExpr",
-			pos:  position{line: 102, col: 1, offset: 2161},
+			pos:  position{line: 157, col: 1, offset: 3629},
 			expr: &actionExpr{
-				pos: position{line: 102, col: 15, offset: 2175},
+				pos: position{line: 157, col: 15, offset: 3643},
 				run: (*parser).callonLogicalExpr1,
 				expr: &seqExpr{
-					pos: position{line: 102, col: 15, offset: 2175},
+					pos: position{line: 157, col: 15, offset: 3643},
 					exprs: []any{
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -443,25 +1028,25 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 102, col: 17, offset: 2177},
+							pos:   position{line: 157, col: 17, offset: 3645},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 102, col: 23, offset: 2183},
+								pos:  position{line: 157, col: 23, offset: 3651},
 								name: "ComparisonExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 102, col: 38, offset: 2198},
+							pos:   position{line: 157, col: 38, offset: 3666},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 102, col: 43, offset: 2203},
+								pos: position{line: 157, col: 43, offset: 3671},
 								expr: &seqExpr{
-									pos: position{line: 102, col: 44, offset: 2204},
+									pos: position{line: 157, col: 44, offset: 3672},
 									exprs: []any{
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -469,19 +1054,19 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 270, col: 13, offset: 6421},
+											pos: position{line: 325, col: 13, offset: 7889},
 											run: (*parser).callonLogicalExpr12,
 											expr: &choiceExpr{
-												pos: position{line: 270, col: 14, offset: 6422},
+												pos: position{line: 325, col: 14, offset: 7890},
 												alternatives: []any{
 													&litMatcher{
-														pos:        position{line: 270, col: 14, offset: 6422},
+														pos:        position{line: 325, col: 14, offset: 7890},
 														val:        "||",
 														ignoreCase: false,
 														want:       "\"||\"",
 													},
 													&litMatcher{
-														pos:        position{line: 270, col: 21, offset: 6429},
+														pos:        position{line: 325, col: 21, offset: 7897},
 														val:        "&&",
 														ignoreCase: false,
 														want:       "\"&&\"",
@@ -490,9 +1075,9 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -500,7 +1085,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 102, col: 58, offset: 2218},
+											pos:  position{line: 157, col: 58, offset: 3686},
 											name: "ComparisonExpr",
 										},
 									},
@@ -508,9 +1093,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -525,17 +1110,17 @@ var g = &grammar{
 		},
 		{
 			name: "ComparisonExpr",
-			pos:  position{line: 106, col: 1, offset: 2281},
+			pos:  position{line: 161, col: 1, offset: 3749},
 			expr: &actionExpr{
-				pos: position{line: 106, col: 18, offset: 2298},
+				pos: position{line: 161, col: 18, offset: 3766},
 				run: (*parser).callonComparisonExpr1,
 				expr: &seqExpr{
-					pos: position{line: 106, col: 18, offset: 2298},
+					pos: position{line: 161, col: 18, offset: 3766},
 					exprs: []any{
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -543,25 +1128,25 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 106, col: 20, offset: 2300},
+							pos:   position{line: 161, col: 20, offset: 3768},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 106, col: 26, offset: 2306},
+								pos:  position{line: 161, col: 26, offset: 3774},
 								name: "ArithmeticExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 106, col: 41, offset: 2321},
+							pos:   position{line: 161, col: 41, offset: 3789},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 106, col: 46, offset: 2326},
+								pos: position{line: 161, col: 46, offset: 3794},
 								expr: &seqExpr{
-									pos: position{line: 106, col: 47, offset: 2327},
+									pos: position{line: 161, col: 47, offset: 3795},
 									exprs: []any{
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -569,44 +1154,44 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 277, col: 16, offset: 6554},
+											pos: position{line: 332, col: 16, offset: 8022},
 											run: (*parser).callonComparisonExpr12,
 											expr: &choiceExpr{
-												pos: position{line: 277, col: 17, offset: 6555},
+												pos: position{line: 332, col: 17, offset: 8023},
 												alternatives: []any{
 													&litMatcher{
-														pos:        position{line: 277, col: 17, offset: 6555},
+														pos:        position{line: 332, col: 17, offset: 8023},
 														val:        "==",
 														ignoreCase: false,
 														want:       "\"==\"",
 													},
 													&litMatcher{
-														pos:        position{line: 277, col: 24, offset: 6562},
+														pos:        position{line: 332, col: 24, offset: 8030},
 														val:        "!=",
 														ignoreCase: false,
 														want:       "\"!=\"",
 													},
 													&litMatcher{
-														pos:        position{line: 277, col: 31, offset: 6569},
+														pos:        position{line: 332, col: 31, offset: 8037},
 														val:        "<=",
 														ignoreCase: false,
 														want:       "\"<=\"",
 													},
 													&litMatcher{
-														pos:        position{line: 277, col: 38, offset: 6576},
+														pos:        position{line: 332, col: 38, offset: 8044},
 														val:        ">=",
 														ignoreCase: false,
 														want:       "\">=\"",
 													},
 													&charClassMatcher{
-														pos:        position{line: 277, col: 45, offset: 6583},
+														pos:        position{line: 332, col: 45, offset: 8051},
 														val:        "[<>]",
 														chars:      []rune{'<', '>'},
 														ignoreCase: false,
 														inverted:   false,
 													},
 													&litMatcher{
-														pos:        position{line: 277, col: 57, offset: 6595},
+														pos:        position{line: 332, col: 57, offset: 8063},
 														val:        "in",
 														ignoreCase: true,
 														want:       "\"in\"i",
@@ -615,9 +1200,9 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -625,7 +1210,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 106, col: 64, offset: 2344},
+											pos:  position{line: 161, col: 64, offset: 3812},
 											name: "ArithmeticExpr",
 										},
 									},
@@ -633,9 +1218,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -650,17 +1235,17 @@ var g = &grammar{
 		},
 		{
 			name: "ArithmeticExpr",
-			pos:  position{line: 110, col: 1, offset: 2407},
+			pos:  position{line: 165, col: 1, offset: 3875},
 			expr: &actionExpr{
-				pos: position{line: 110, col: 18, offset: 2424},
+				pos: position{line: 165, col: 18, offset: 3892},
 				run: (*parser).callonArithmeticExpr1,
 				expr: &seqExpr{
-					pos: position{line: 110, col: 18, offset: 2424},
+					pos: position{line: 165, col: 18, offset: 3892},
 					exprs: []any{
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -668,25 +1253,25 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 110, col: 20, offset: 2426},
+							pos:   position{line: 165, col: 20, offset: 3894},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 110, col: 26, offset: 2432},
+								pos:  position{line: 165, col: 26, offset: 3900},
 								name: "Value",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 110, col: 32, offset: 2438},
+							pos:   position{line: 165, col: 32, offset: 3906},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 110, col: 37, offset: 2443},
+								pos: position{line: 165, col: 37, offset: 3911},
 								expr: &seqExpr{
-									pos: position{line: 110, col: 38, offset: 2444},
+									pos: position{line: 165, col: 38, offset: 3912},
 									exprs: []any{
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -694,10 +1279,10 @@ var g = &grammar{
 											},
 										},
 										&actionExpr{
-											pos: position{line: 284, col: 16, offset: 6721},
+											pos: position{line: 339, col: 16, offset: 8189},
 											run: (*parser).callonArithmeticExpr12,
 											expr: &charClassMatcher{
-												pos:        position{line: 284, col: 17, offset: 6722},
+												pos:        position{line: 339, col: 17, offset: 8190},
 												val:        "[+-/*%]",
 												chars:      []rune{'+', '-', '/', '*', '%'},
 												ignoreCase: false,
@@ -705,9 +1290,9 @@ var g = &grammar{
 											},
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -715,7 +1300,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 110, col: 55, offset: 2461},
+											pos:  position{line: 165, col: 55, offset: 3929},
 											name: "Value",
 										},
 									},
@@ -723,9 +1308,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -740,29 +1325,29 @@ var g = &grammar{
 		},
 		{
 			name: "ParenExpr",
-			pos:  position{line: 114, col: 1, offset: 2515},
+			pos:  position{line: 169, col: 1, offset: 3983},
 			expr: &actionExpr{
-				pos: position{line: 114, col: 13, offset: 2527},
+				pos: position{line: 169, col: 13, offset: 3995},
 				run: (*parser).callonParenExpr1,
 				expr: &seqExpr{
-					pos: position{line: 114, col: 13, offset: 2527},
+					pos: position{line: 169, col: 13, offset: 3995},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 114, col: 13, offset: 2527},
+							pos:        position{line: 169, col: 13, offset: 3995},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 114, col: 17, offset: 2531},
+							pos:   position{line: 169, col: 17, offset: 3999},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 114, col: 22, offset: 2536},
+								pos:  position{line: 169, col: 22, offset: 4004},
 								name: "Expr",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 114, col: 27, offset: 2541},
+							pos:        position{line: 169, col: 27, offset: 4009},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -775,46 +1360,46 @@ var g = &grammar{
 		},
 		{
 			name: "ParamList",
-			pos:  position{line: 118, col: 1, offset: 2571},
+			pos:  position{line: 173, col: 1, offset: 4039},
 			expr: &actionExpr{
-				pos: position{line: 118, col: 13, offset: 2583},
+				pos: position{line: 173, col: 13, offset: 4051},
 				run: (*parser).callonParamList1,
 				expr: &seqExpr{
-					pos: position{line: 118, col: 13, offset: 2583},
+					pos: position{line: 173, col: 13, offset: 4051},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 118, col: 13, offset: 2583},
+							pos:        position{line: 173, col: 13, offset: 4051},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 118, col: 17, offset: 2587},
+							pos:   position{line: 173, col: 17, offset: 4055},
 							label: "params",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 118, col: 24, offset: 2594},
+								pos: position{line: 173, col: 24, offset: 4062},
 								expr: &seqExpr{
-									pos: position{line: 118, col: 25, offset: 2595},
+									pos: position{line: 173, col: 25, offset: 4063},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 118, col: 25, offset: 2595},
+											pos:  position{line: 173, col: 25, offset: 4063},
 											name: "Expr",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 118, col: 30, offset: 2600},
+											pos: position{line: 173, col: 30, offset: 4068},
 											expr: &seqExpr{
-												pos: position{line: 118, col: 32, offset: 2602},
+												pos: position{line: 173, col: 32, offset: 4070},
 												exprs: []any{
 													&litMatcher{
-														pos:        position{line: 118, col: 32, offset: 2602},
+														pos:        position{line: 173, col: 32, offset: 4070},
 														val:        ",",
 														ignoreCase: false,
 														want:       "\",\"",
 													},
 													&zeroOrMoreExpr{
-														pos: position{line: 297, col: 18, offset: 7012},
+														pos: position{line: 352, col: 18, offset: 8480},
 														expr: &charClassMatcher{
-															pos:        position{line: 297, col: 18, offset: 7012},
+															pos:        position{line: 352, col: 18, offset: 8480},
 															val:        "[ \\t\\r\\n]",
 															chars:      []rune{' ', '\t', '\r', '\n'},
 															ignoreCase: false,
@@ -822,7 +1407,7 @@ var g = &grammar{
 														},
 													},
 													&ruleRefExpr{
-														pos:  position{line: 118, col: 38, offset: 2608},
+														pos:  position{line: 173, col: 38, offset: 4076},
 														name: "Expr",
 													},
 												},
@@ -833,7 +1418,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 118, col: 49, offset: 2619},
+							pos:        position{line: 173, col: 49, offset: 4087},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -846,20 +1431,20 @@ var g = &grammar{
 		},
 		{
 			name: "Value",
-			pos:  position{line: 132, col: 1, offset: 2981},
+			pos:  position{line: 187, col: 1, offset: 4449},
 			expr: &actionExpr{
-				pos: position{line: 132, col: 9, offset: 2989},
+				pos: position{line: 187, col: 9, offset: 4457},
 				run: (*parser).callonValue1,
 				expr: &seqExpr{
-					pos: position{line: 132, col: 9, offset: 2989},
+					pos: position{line: 187, col: 9, offset: 4457},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 132, col: 9, offset: 2989},
+							pos:   position{line: 187, col: 9, offset: 4457},
 							label: "not",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 132, col: 13, offset: 2993},
+								pos: position{line: 187, col: 13, offset: 4461},
 								expr: &litMatcher{
-									pos:        position{line: 132, col: 13, offset: 2993},
+									pos:        position{line: 187, col: 13, offset: 4461},
 									val:        "!",
 									ignoreCase: false,
 									want:       "\"!\"",
@@ -867,52 +1452,52 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 132, col: 18, offset: 2998},
+							pos:   position{line: 187, col: 18, offset: 4466},
 							label: "node",
 							expr: &choiceExpr{
-								pos: position{line: 132, col: 24, offset: 3004},
+								pos: position{line: 187, col: 24, offset: 4472},
 								alternatives: []any{
 									&actionExpr{
-										pos: position{line: 291, col: 7, offset: 6861},
+										pos: position{line: 346, col: 7, offset: 8329},
 										run: (*parser).callonValue8,
 										expr: &litMatcher{
-											pos:        position{line: 291, col: 7, offset: 6861},
+											pos:        position{line: 346, col: 7, offset: 8329},
 											val:        "nil",
 											ignoreCase: false,
 											want:       "\"nil\"",
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 30, offset: 3010},
+										pos:  position{line: 187, col: 30, offset: 4478},
 										name: "MethodCall",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 43, offset: 3023},
+										pos:  position{line: 187, col: 43, offset: 4491},
 										name: "FieldAccess",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 57, offset: 3037},
+										pos:  position{line: 187, col: 57, offset: 4505},
 										name: "Index",
 									},
 									&actionExpr{
-										pos: position{line: 246, col: 10, offset: 5924},
+										pos: position{line: 301, col: 10, offset: 7392},
 										run: (*parser).callonValue13,
 										expr: &seqExpr{
-											pos: position{line: 246, col: 10, offset: 5924},
+											pos: position{line: 301, col: 10, offset: 7392},
 											exprs: []any{
 												&litMatcher{
-													pos:        position{line: 246, col: 10, offset: 5924},
+													pos:        position{line: 301, col: 10, offset: 7392},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
 												},
 												&labeledExpr{
-													pos:   position{line: 246, col: 14, offset: 5928},
+													pos:   position{line: 301, col: 14, offset: 7396},
 													label: "value",
 													expr: &zeroOrMoreExpr{
-														pos: position{line: 246, col: 20, offset: 5934},
+														pos: position{line: 301, col: 20, offset: 7402},
 														expr: &charClassMatcher{
-															pos:        position{line: 246, col: 20, offset: 5934},
+															pos:        position{line: 301, col: 20, offset: 7402},
 															val:        "[^\"]",
 															chars:      []rune{'"'},
 															ignoreCase: false,
@@ -921,7 +1506,7 @@ var g = &grammar{
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 246, col: 26, offset: 5940},
+													pos:        position{line: 301, col: 26, offset: 7408},
 													val:        "\"",
 													ignoreCase: false,
 													want:       "\"\\\"\"",
@@ -930,24 +1515,24 @@ var g = &grammar{
 										},
 									},
 									&actionExpr{
-										pos: position{line: 254, col: 13, offset: 6091},
+										pos: position{line: 309, col: 13, offset: 7559},
 										run: (*parser).callonValue20,
 										expr: &seqExpr{
-											pos: position{line: 254, col: 13, offset: 6091},
+											pos: position{line: 309, col: 13, offset: 7559},
 											exprs: []any{
 												&litMatcher{
-													pos:        position{line: 254, col: 13, offset: 6091},
+													pos:        position{line: 309, col: 13, offset: 7559},
 													val:        "`",
 													ignoreCase: false,
 													want:       "\"`\"",
 												},
 												&labeledExpr{
-													pos:   position{line: 254, col: 17, offset: 6095},
+													pos:   position{line: 309, col: 17, offset: 7563},
 													label: "value",
 													expr: &zeroOrMoreExpr{
-														pos: position{line: 254, col: 23, offset: 6101},
+														pos: position{line: 309, col: 23, offset: 7569},
 														expr: &charClassMatcher{
-															pos:        position{line: 254, col: 23, offset: 6101},
+															pos:        position{line: 309, col: 23, offset: 7569},
 															val:        "[^`]",
 															chars:      []rune{'`'},
 															ignoreCase: false,
@@ -956,7 +1541,7 @@ var g = &grammar{
 													},
 												},
 												&litMatcher{
-													pos:        position{line: 254, col: 29, offset: 6107},
+													pos:        position{line: 309, col: 29, offset: 7575},
 													val:        "`",
 													ignoreCase: false,
 													want:       "\"`\"",
@@ -965,30 +1550,30 @@ var g = &grammar{
 										},
 									},
 									&actionExpr{
-										pos: position{line: 238, col: 9, offset: 5743},
+										pos: position{line: 293, col: 9, offset: 7211},
 										run: (*parser).callonValue27,
 										expr: &seqExpr{
-											pos: position{line: 238, col: 9, offset: 5743},
+											pos: position{line: 293, col: 9, offset: 7211},
 											exprs: []any{
 												&zeroOrOneExpr{
-													pos: position{line: 238, col: 9, offset: 5743},
+													pos: position{line: 293, col: 9, offset: 7211},
 													expr: &litMatcher{
-														pos:        position{line: 238, col: 9, offset: 5743},
+														pos:        position{line: 293, col: 9, offset: 7211},
 														val:        "-",
 														ignoreCase: false,
 														want:       "\"-\"",
 													},
 												},
 												&labeledExpr{
-													pos:   position{line: 238, col: 14, offset: 5748},
+													pos:   position{line: 293, col: 14, offset: 7216},
 													label: "value",
 													expr: &seqExpr{
-														pos: position{line: 238, col: 21, offset: 5755},
+														pos: position{line: 293, col: 21, offset: 7223},
 														exprs: []any{
 															&oneOrMoreExpr{
-																pos: position{line: 238, col: 21, offset: 5755},
+																pos: position{line: 293, col: 21, offset: 7223},
 																expr: &charClassMatcher{
-																	pos:        position{line: 238, col: 21, offset: 5755},
+																	pos:        position{line: 293, col: 21, offset: 7223},
 																	val:        "[0-9]",
 																	ranges:     []rune{'0', '9'},
 																	ignoreCase: false,
@@ -996,15 +1581,15 @@ var g = &grammar{
 																},
 															},
 															&litMatcher{
-																pos:        position{line: 238, col: 28, offset: 5762},
+																pos:        position{line: 293, col: 28, offset: 7230},
 																val:        ".",
 																ignoreCase: false,
 																want:       "\".\"",
 															},
 															&oneOrMoreExpr{
-																pos: position{line: 238, col: 32, offset: 5766},
+																pos: position{line: 293, col: 32, offset: 7234},
 																expr: &charClassMatcher{
-																	pos:        position{line: 238, col: 32, offset: 5766},
+																	pos:        position{line: 293, col: 32, offset: 7234},
 																	val:        "[0-9]",
 																	ranges:     []rune{'0', '9'},
 																	ignoreCase: false,
@@ -1018,36 +1603,36 @@ var g = &grammar{
 										},
 									},
 									&actionExpr{
-										pos: position{line: 230, col: 11, offset: 5532},
+										pos: position{line: 285, col: 11, offset: 7000},
 										run: (*parser).callonValue38,
 										expr: &seqExpr{
-											pos: position{line: 230, col: 11, offset: 5532},
+											pos: position{line: 285, col: 11, offset: 7000},
 											exprs: []any{
 												&zeroOrOneExpr{
-													pos: position{line: 230, col: 11, offset: 5532},
+													pos: position{line: 285, col: 11, offset: 7000},
 													expr: &litMatcher{
-														pos:        position{line: 230, col: 11, offset: 5532},
+														pos:        position{line: 285, col: 11, offset: 7000},
 														val:        "-",
 														ignoreCase: false,
 														want:       "\"-\"",
 													},
 												},
 												&choiceExpr{
-													pos: position{line: 230, col: 17, offset: 5538},
+													pos: position{line: 285, col: 17, offset: 7006},
 													alternatives: []any{
 														&seqExpr{
-															pos: position{line: 230, col: 17, offset: 5538},
+															pos: position{line: 285, col: 17, offset: 7006},
 															exprs: []any{
 																&litMatcher{
-																	pos:        position{line: 230, col: 17, offset: 5538},
+																	pos:        position{line: 285, col: 17, offset: 7006},
 																	val:        "0x",
 																	ignoreCase: false,
 																	want:       "\"0x\"",
 																},
 																&oneOrMoreExpr{
-																	pos: position{line: 230, col: 22, offset: 5543},
+																	pos: position{line: 285, col: 22, offset: 7011},
 																	expr: &charClassMatcher{
-																		pos:        position{line: 230, col: 22, offset: 5543},
+																		pos:        position{line: 285, col: 22, offset: 7011},
 																		val:        "[0-9a-f]i",
 																		ranges:     []rune{'0', '9', 'a', 'f'},
 																		ignoreCase: true,
@@ -1057,18 +1642,18 @@ var g = &grammar{
 															},
 														},
 														&seqExpr{
-															pos: position{line: 230, col: 35, offset: 5556},
+															pos: position{line: 285, col: 35, offset: 7024},
 															exprs: []any{
 																&litMatcher{
-																	pos:        position{line: 230, col: 35, offset: 5556},
+																	pos:        position{line: 285, col: 35, offset: 7024},
 																	val:        "0o",
 																	ignoreCase: false,
 																	want:       "\"0o\"",
 																},
 																&oneOrMoreExpr{
-																	pos: position{line: 230, col: 40, offset: 5561},
+																	pos: position{line: 285, col: 40, offset: 7029},
 																	expr: &charClassMatcher{
-																		pos:        position{line: 230, col: 40, offset: 5561},
+																		pos:        position{line: 285, col: 40, offset: 7029},
 																		val:        "[0-7]",
 																		ranges:     []rune{'0', '7'},
 																		ignoreCase: false,
@@ -1078,18 +1663,18 @@ var g = &grammar{
 															},
 														},
 														&seqExpr{
-															pos: position{line: 230, col: 49, offset: 5570},
+															pos: position{line: 285, col: 49, offset: 7038},
 															exprs: []any{
 																&litMatcher{
-																	pos:        position{line: 230, col: 49, offset: 5570},
+																	pos:        position{line: 285, col: 49, offset: 7038},
 																	val:        "0b",
 																	ignoreCase: false,
 																	want:       "\"0b\"",
 																},
 																&oneOrMoreExpr{
-																	pos: position{line: 230, col: 54, offset: 5575},
+																	pos: position{line: 285, col: 54, offset: 7043},
 																	expr: &charClassMatcher{
-																		pos:        position{line: 230, col: 54, offset: 5575},
+																		pos:        position{line: 285, col: 54, offset: 7043},
 																		val:        "[01]",
 																		chars:      []rune{'0', '1'},
 																		ignoreCase: false,
@@ -1099,9 +1684,9 @@ var g = &grammar{
 															},
 														},
 														&oneOrMoreExpr{
-															pos: position{line: 230, col: 62, offset: 5583},
+															pos: position{line: 285, col: 62, offset: 7051},
 															expr: &charClassMatcher{
-																pos:        position{line: 230, col: 62, offset: 5583},
+																pos:        position{line: 285, col: 62, offset: 7051},
 																val:        "[0-9]",
 																ranges:     []rune{'0', '9'},
 																ignoreCase: false,
@@ -1114,19 +1699,19 @@ var g = &grammar{
 										},
 									},
 									&actionExpr{
-										pos: position{line: 262, col: 8, offset: 6253},
+										pos: position{line: 317, col: 8, offset: 7721},
 										run: (*parser).callonValue57,
 										expr: &choiceExpr{
-											pos: position{line: 262, col: 9, offset: 6254},
+											pos: position{line: 317, col: 9, offset: 7722},
 											alternatives: []any{
 												&litMatcher{
-													pos:        position{line: 262, col: 9, offset: 6254},
+													pos:        position{line: 317, col: 9, offset: 7722},
 													val:        "true",
 													ignoreCase: true,
 													want:       "\"true\"i",
 												},
 												&litMatcher{
-													pos:        position{line: 262, col: 19, offset: 6264},
+													pos:        position{line: 317, col: 19, offset: 7732},
 													val:        "false",
 													ignoreCase: true,
 													want:       "\"false\"i",
@@ -1135,30 +1720,30 @@ var g = &grammar{
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 111, offset: 3091},
+										pos:  position{line: 187, col: 111, offset: 4559},
 										name: "FuncCall",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 122, offset: 3102},
+										pos:  position{line: 187, col: 122, offset: 4570},
 										name: "VariableOr",
 									},
 									&actionExpr{
-										pos: position{line: 215, col: 9, offset: 5217},
+										pos: position{line: 270, col: 9, offset: 6685},
 										run: (*parser).callonValue63,
 										expr: &seqExpr{
-											pos: position{line: 215, col: 9, offset: 5217},
+											pos: position{line: 270, col: 9, offset: 6685},
 											exprs: []any{
 												&charClassMatcher{
-													pos:        position{line: 215, col: 9, offset: 5217},
+													pos:        position{line: 270, col: 9, offset: 6685},
 													val:        "[a-z]i",
 													ranges:     []rune{'a', 'z'},
 													ignoreCase: true,
 													inverted:   false,
 												},
 												&zeroOrMoreExpr{
-													pos: position{line: 215, col: 16, offset: 5224},
+													pos: position{line: 270, col: 16, offset: 6692},
 													expr: &charClassMatcher{
-														pos:        position{line: 215, col: 16, offset: 5224},
+														pos:        position{line: 270, col: 16, offset: 6692},
 														val:        "[_a-z0-9]i",
 														chars:      []rune{'_'},
 														ranges:     []rune{'a', 'z', '0', '9'},
@@ -1170,15 +1755,15 @@ var g = &grammar{
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 143, offset: 3123},
+										pos:  position{line: 187, col: 143, offset: 4591},
 										name: "ParenExpr",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 155, offset: 3135},
+										pos:  position{line: 187, col: 155, offset: 4603},
 										name: "Array",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 132, col: 163, offset: 3143},
+										pos:  position{line: 187, col: 163, offset: 4611},
 										name: "Map",
 									},
 								},
@@ -1192,23 +1777,23 @@ var g = &grammar{
 		},
 		{
 			name: "Map",
-			pos:  position{line: 139, col: 1, offset: 3243},
+			pos:  position{line: 194, col: 1, offset: 4711},
 			expr: &actionExpr{
-				pos: position{line: 139, col: 7, offset: 3249},
+				pos: position{line: 194, col: 7, offset: 4717},
 				run: (*parser).callonMap1,
 				expr: &seqExpr{
-					pos: position{line: 139, col: 7, offset: 3249},
+					pos: position{line: 194, col: 7, offset: 4717},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 139, col: 7, offset: 3249},
+							pos:        position{line: 194, col: 7, offset: 4717},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1216,21 +1801,21 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 13, offset: 3255},
+							pos:   position{line: 194, col: 13, offset: 4723},
 							label: "fpair",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 139, col: 19, offset: 3261},
+								pos: position{line: 194, col: 19, offset: 4729},
 								expr: &seqExpr{
-									pos: position{line: 139, col: 20, offset: 3262},
+									pos: position{line: 194, col: 20, offset: 4730},
 									exprs: []any{
 										&ruleRefExpr{
-											pos:  position{line: 139, col: 20, offset: 3262},
+											pos:  position{line: 194, col: 20, offset: 4730},
 											name: "Assignable",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1238,15 +1823,15 @@ var g = &grammar{
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 139, col: 33, offset: 3275},
+											pos:        position{line: 194, col: 33, offset: 4743},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1254,7 +1839,7 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 139, col: 39, offset: 3281},
+											pos:  position{line: 194, col: 39, offset: 4749},
 											name: "Assignable",
 										},
 									},
@@ -1262,9 +1847,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1272,23 +1857,23 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 139, col: 54, offset: 3296},
+							pos:   position{line: 194, col: 54, offset: 4764},
 							label: "pairs",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 139, col: 60, offset: 3302},
+								pos: position{line: 194, col: 60, offset: 4770},
 								expr: &seqExpr{
-									pos: position{line: 139, col: 61, offset: 3303},
+									pos: position{line: 194, col: 61, offset: 4771},
 									exprs: []any{
 										&litMatcher{
-											pos:        position{line: 139, col: 61, offset: 3303},
+											pos:        position{line: 194, col: 61, offset: 4771},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1296,13 +1881,13 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 139, col: 67, offset: 3309},
+											pos:  position{line: 194, col: 67, offset: 4777},
 											name: "Assignable",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1310,15 +1895,15 @@ var g = &grammar{
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 139, col: 80, offset: 3322},
+											pos:        position{line: 194, col: 80, offset: 4790},
 											val:        ":",
 											ignoreCase: false,
 											want:       "\":\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1326,13 +1911,13 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 139, col: 86, offset: 3328},
+											pos:  position{line: 194, col: 86, offset: 4796},
 											name: "Assignable",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1344,9 +1929,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1354,18 +1939,18 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 139, col: 103, offset: 3345},
+							pos: position{line: 194, col: 103, offset: 4813},
 							expr: &litMatcher{
-								pos:        position{line: 139, col: 103, offset: 3345},
+								pos:        position{line: 194, col: 103, offset: 4813},
 								val:        ",",
 								ignoreCase: false,
 								want:       "\",\"",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1373,7 +1958,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 139, col: 110, offset: 3352},
+							pos:        position{line: 194, col: 110, offset: 4820},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -1386,23 +1971,23 @@ var g = &grammar{
 		},
 		{
 			name: "Array",
-			pos:  position{line: 159, col: 1, offset: 3823},
+			pos:  position{line: 214, col: 1, offset: 5291},
 			expr: &actionExpr{
-				pos: position{line: 159, col: 9, offset: 3831},
+				pos: position{line: 214, col: 9, offset: 5299},
 				run: (*parser).callonArray1,
 				expr: &seqExpr{
-					pos: position{line: 159, col: 9, offset: 3831},
+					pos: position{line: 214, col: 9, offset: 5299},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 159, col: 9, offset: 3831},
+							pos:        position{line: 214, col: 9, offset: 5299},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1410,20 +1995,20 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 15, offset: 3837},
+							pos:   position{line: 214, col: 15, offset: 5305},
 							label: "fval",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 159, col: 20, offset: 3842},
+								pos: position{line: 214, col: 20, offset: 5310},
 								expr: &ruleRefExpr{
-									pos:  position{line: 159, col: 20, offset: 3842},
+									pos:  position{line: 214, col: 20, offset: 5310},
 									name: "Assignable",
 								},
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1431,23 +2016,23 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 159, col: 34, offset: 3856},
+							pos:   position{line: 214, col: 34, offset: 5324},
 							label: "vals",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 159, col: 39, offset: 3861},
+								pos: position{line: 214, col: 39, offset: 5329},
 								expr: &seqExpr{
-									pos: position{line: 159, col: 40, offset: 3862},
+									pos: position{line: 214, col: 40, offset: 5330},
 									exprs: []any{
 										&litMatcher{
-											pos:        position{line: 159, col: 40, offset: 3862},
+											pos:        position{line: 214, col: 40, offset: 5330},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1455,13 +2040,13 @@ var g = &grammar{
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 159, col: 46, offset: 3868},
+											pos:  position{line: 214, col: 46, offset: 5336},
 											name: "Assignable",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 297, col: 18, offset: 7012},
+											pos: position{line: 352, col: 18, offset: 8480},
 											expr: &charClassMatcher{
-												pos:        position{line: 297, col: 18, offset: 7012},
+												pos:        position{line: 352, col: 18, offset: 8480},
 												val:        "[ \\t\\r\\n]",
 												chars:      []rune{' ', '\t', '\r', '\n'},
 												ignoreCase: false,
@@ -1473,18 +2058,18 @@ var g = &grammar{
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 159, col: 61, offset: 3883},
+							pos: position{line: 214, col: 61, offset: 5351},
 							expr: &litMatcher{
-								pos:        position{line: 159, col: 61, offset: 3883},
+								pos:        position{line: 214, col: 61, offset: 5351},
 								val:        ",",
 								ignoreCase: false,
 								want:       "\",\"",
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1492,7 +2077,7 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 159, col: 68, offset: 3890},
+							pos:        position{line: 214, col: 68, offset: 5358},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -1505,33 +2090,33 @@ var g = &grammar{
 		},
 		{
 			name: "VariableOr",
-			pos:  position{line: 175, col: 1, offset: 4245},
+			pos:  position{line: 230, col: 1, offset: 5713},
 			expr: &actionExpr{
-				pos: position{line: 175, col: 14, offset: 4258},
+				pos: position{line: 230, col: 14, offset: 5726},
 				run: (*parser).callonVariableOr1,
 				expr: &seqExpr{
-					pos: position{line: 175, col: 14, offset: 4258},
+					pos: position{line: 230, col: 14, offset: 5726},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 175, col: 14, offset: 4258},
+							pos:   position{line: 230, col: 14, offset: 5726},
 							label: "variable",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonVariableOr4,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -1544,9 +2129,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1554,15 +2139,15 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 175, col: 31, offset: 4275},
+							pos:        position{line: 230, col: 31, offset: 5743},
 							val:        "|",
 							ignoreCase: false,
 							want:       "\"|\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1570,10 +2155,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 175, col: 37, offset: 4281},
+							pos:   position{line: 230, col: 37, offset: 5749},
 							label: "or",
 							expr: &ruleRefExpr{
-								pos:  position{line: 175, col: 40, offset: 4284},
+								pos:  position{line: 230, col: 40, offset: 5752},
 								name: "Assignable",
 							},
 						},
@@ -1585,33 +2170,33 @@ var g = &grammar{
 		},
 		{
 			name: "Assignment",
-			pos:  position{line: 182, col: 1, offset: 4411},
+			pos:  position{line: 237, col: 1, offset: 5879},
 			expr: &actionExpr{
-				pos: position{line: 182, col: 14, offset: 4424},
+				pos: position{line: 237, col: 14, offset: 5892},
 				run: (*parser).callonAssignment1,
 				expr: &seqExpr{
-					pos: position{line: 182, col: 14, offset: 4424},
+					pos: position{line: 237, col: 14, offset: 5892},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 182, col: 14, offset: 4424},
+							pos:   position{line: 237, col: 14, offset: 5892},
 							label: "name",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonAssignment4,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -1624,9 +2209,9 @@ var g = &grammar{
 							},
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1634,15 +2219,15 @@ var g = &grammar{
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 182, col: 27, offset: 4437},
+							pos:        position{line: 237, col: 27, offset: 5905},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 297, col: 18, offset: 7012},
+							pos: position{line: 352, col: 18, offset: 8480},
 							expr: &charClassMatcher{
-								pos:        position{line: 297, col: 18, offset: 7012},
+								pos:        position{line: 352, col: 18, offset: 8480},
 								val:        "[ \\t\\r\\n]",
 								chars:      []rune{' ', '\t', '\r', '\n'},
 								ignoreCase: false,
@@ -1650,10 +2235,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 182, col: 33, offset: 4443},
+							pos:   position{line: 237, col: 33, offset: 5911},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 182, col: 39, offset: 4449},
+								pos:  position{line: 237, col: 39, offset: 5917},
 								name: "Assignable",
 							},
 						},
@@ -1665,47 +2250,47 @@ var g = &grammar{
 		},
 		{
 			name: "MethodCall",
-			pos:  position{line: 190, col: 1, offset: 4604},
+			pos:  position{line: 245, col: 1, offset: 6072},
 			expr: &actionExpr{
-				pos: position{line: 190, col: 14, offset: 4617},
+				pos: position{line: 245, col: 14, offset: 6085},
 				run: (*parser).callonMethodCall1,
 				expr: &seqExpr{
-					pos: position{line: 190, col: 14, offset: 4617},
+					pos: position{line: 245, col: 14, offset: 6085},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 190, col: 14, offset: 4617},
+							pos:   position{line: 245, col: 14, offset: 6085},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 190, col: 20, offset: 4623},
+								pos:  position{line: 245, col: 20, offset: 6091},
 								name: "Value",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 190, col: 26, offset: 4629},
+							pos:        position{line: 245, col: 26, offset: 6097},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 190, col: 30, offset: 4633},
+							pos:   position{line: 245, col: 30, offset: 6101},
 							label: "name",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonMethodCall7,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -1718,10 +2303,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 190, col: 41, offset: 4644},
+							pos:   position{line: 245, col: 41, offset: 6112},
 							label: "params",
 							expr: &ruleRefExpr{
-								pos:  position{line: 190, col: 48, offset: 4651},
+								pos:  position{line: 245, col: 48, offset: 6119},
 								name: "ParamList",
 							},
 						},
@@ -1733,37 +2318,37 @@ var g = &grammar{
 		},
 		{
 			name: "Index",
-			pos:  position{line: 199, col: 1, offset: 4844},
+			pos:  position{line: 254, col: 1, offset: 6312},
 			expr: &actionExpr{
-				pos: position{line: 199, col: 9, offset: 4852},
+				pos: position{line: 254, col: 9, offset: 6320},
 				run: (*parser).callonIndex1,
 				expr: &seqExpr{
-					pos: position{line: 199, col: 9, offset: 4852},
+					pos: position{line: 254, col: 9, offset: 6320},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 199, col: 9, offset: 4852},
+							pos:   position{line: 254, col: 9, offset: 6320},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 199, col: 15, offset: 4858},
+								pos:  position{line: 254, col: 15, offset: 6326},
 								name: "Value",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 199, col: 21, offset: 4864},
+							pos:        position{line: 254, col: 21, offset: 6332},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 199, col: 25, offset: 4868},
+							pos:   position{line: 254, col: 25, offset: 6336},
 							label: "index",
 							expr: &ruleRefExpr{
-								pos:  position{line: 199, col: 31, offset: 4874},
+								pos:  position{line: 254, col: 31, offset: 6342},
 								name: "Value",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 199, col: 37, offset: 4880},
+							pos:        position{line: 254, col: 37, offset: 6348},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -1776,47 +2361,47 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAccess",
-			pos:  position{line: 207, col: 1, offset: 5023},
+			pos:  position{line: 262, col: 1, offset: 6491},
 			expr: &actionExpr{
-				pos: position{line: 207, col: 15, offset: 5037},
+				pos: position{line: 262, col: 15, offset: 6505},
 				run: (*parser).callonFieldAccess1,
 				expr: &seqExpr{
-					pos: position{line: 207, col: 15, offset: 5037},
+					pos: position{line: 262, col: 15, offset: 6505},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 207, col: 15, offset: 5037},
+							pos:   position{line: 262, col: 15, offset: 6505},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 207, col: 21, offset: 5043},
+								pos:  position{line: 262, col: 21, offset: 6511},
 								name: "Value",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 207, col: 27, offset: 5049},
+							pos:        position{line: 262, col: 27, offset: 6517},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 207, col: 31, offset: 5053},
+							pos:   position{line: 262, col: 31, offset: 6521},
 							label: "name",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonFieldAccess7,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -1836,33 +2421,33 @@ var g = &grammar{
 		},
 		{
 			name: "FuncCall",
-			pos:  position{line: 222, col: 1, offset: 5338},
+			pos:  position{line: 277, col: 1, offset: 6806},
 			expr: &actionExpr{
-				pos: position{line: 222, col: 12, offset: 5349},
+				pos: position{line: 277, col: 12, offset: 6817},
 				run: (*parser).callonFuncCall1,
 				expr: &seqExpr{
-					pos: position{line: 222, col: 12, offset: 5349},
+					pos: position{line: 277, col: 12, offset: 6817},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 222, col: 12, offset: 5349},
+							pos:   position{line: 277, col: 12, offset: 6817},
 							label: "name",
 							expr: &actionExpr{
-								pos: position{line: 215, col: 9, offset: 5217},
+								pos: position{line: 270, col: 9, offset: 6685},
 								run: (*parser).callonFuncCall4,
 								expr: &seqExpr{
-									pos: position{line: 215, col: 9, offset: 5217},
+									pos: position{line: 270, col: 9, offset: 6685},
 									exprs: []any{
 										&charClassMatcher{
-											pos:        position{line: 215, col: 9, offset: 5217},
+											pos:        position{line: 270, col: 9, offset: 6685},
 											val:        "[a-z]i",
 											ranges:     []rune{'a', 'z'},
 											ignoreCase: true,
 											inverted:   false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 215, col: 16, offset: 5224},
+											pos: position{line: 270, col: 16, offset: 6692},
 											expr: &charClassMatcher{
-												pos:        position{line: 215, col: 16, offset: 5224},
+												pos:        position{line: 270, col: 16, offset: 6692},
 												val:        "[_a-z0-9]i",
 												chars:      []rune{'_'},
 												ranges:     []rune{'a', 'z', '0', '9'},
@@ -1875,10 +2460,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 23, offset: 5360},
+							pos:   position{line: 277, col: 23, offset: 6828},
 							label: "params",
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 30, offset: 5367},
+								pos:  position{line: 277, col: 30, offset: 6835},
 								name: "ParamList",
 							},
 						},
@@ -1891,7 +2476,7 @@ var g = &grammar{
 	},
 }
 
-func (c *current) onRoot11() (any, error) {
+func (c *current) onRoot12() (any, error) {
 
 	return ast.Ident{
 		Value:    string(c.text),
@@ -1899,33 +2484,33 @@ func (c *current) onRoot11() (any, error) {
 	}, nil
 }
 
-func (p *parser) callonRoot11() (any, error) {
+func (p *parser) callonRoot12() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRoot11()
+	return p.cur.onRoot12()
 }
 
-func (c *current) onRoot7(name any) (any, error) {
+func (c *current) onRoot8(name any) (any, error) {
 	return ast.EndTag{
 		Name:     name.(ast.Ident),
 		Position: getPos(c),
 	}, nil
 }
 
-func (p *parser) callonRoot7() (any, error) {
+func (p *parser) callonRoot8() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRoot7(stack["name"])
+	return p.cur.onRoot8(stack["name"])
 }
 
-func (c *current) onRoot16() (any, error) {
+func (c *current) onRoot17() (any, error) {
 	return ast.Text{Data: c.text, Position: getPos(c)}, nil
 }
 
-func (p *parser) callonRoot16() (any, error) {
+func (p *parser) callonRoot17() (any, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onRoot16()
+	return p.cur.onRoot17()
 }
 
 func (c *current) onRoot1(items any) (any, error) {
@@ -1972,6 +2557,223 @@ func (p *parser) callonTag1() (any, error) {
 	return p.cur.onTag1(stack["name"], stack["params"], stack["body"])
 }
 
+func (c *current) onForTag5() (any, error) {
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForTag5() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag5()
+}
+
+func (c *current) onForTag20() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForTag20() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag20()
+}
+
+func (c *current) onForTag33() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForTag33() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag33()
+}
+
+func (c *current) onForTag14(first, rest any) (any, error) {
+	out := ast.Array{
+		Array:    []ast.Node{first.(ast.Node)},
+		Position: getPos(c),
+	}
+	for _, value := range toAnySlice(rest) {
+		out.Array = append(out.Array, toAnySlice(value)[3].(ast.Node))
+	}
+	return out, nil
+}
+
+func (p *parser) callonForTag14() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag14(stack["first"], stack["rest"])
+}
+
+func (c *current) onForTag43() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForTag43() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag43()
+}
+
+func (c *current) onForTag41(name any) (any, error) {
+	return ast.Value{Node: name.(ast.Node)}, nil
+}
+
+func (p *parser) callonForTag41() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag41(stack["name"])
+}
+
+func (c *current) onForTag58(name, vars, last any) (bool, error) {
+	for _, v := range toAnySlice(vars) {
+		if _, ok := toAnySlice(v)[0].(ast.Array); ok {
+			return true, nil
+		}
+	}
+	_, ok := last.(ast.Expr).First.(ast.Array)
+	return ok, nil
+}
+
+func (p *parser) callonForTag58() (bool, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag58(stack["name"], stack["vars"], stack["last"])
+}
+
+func (c *current) onForTag1(name, vars, last, body any) (any, error) {
+	var params []ast.Node
+	for _, v := range toAnySlice(vars) {
+		params = append(params, toAnySlice(v)[0].(ast.Node))
+	}
+	return ast.Tag{
+		Name:     name.(ast.Ident),
+		Params:   append(params, last.(ast.Node)),
+		HasBody:  body != nil,
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForTag1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForTag1(stack["name"], stack["vars"], stack["last"], stack["body"])
+}
+
+func (c *current) onForInExpr11() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForInExpr11() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr11()
+}
+
+func (c *current) onForInExpr24() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForInExpr24() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr24()
+}
+
+func (c *current) onForInExpr5(first, rest any) (any, error) {
+	out := ast.Array{
+		Array:    []ast.Node{first.(ast.Node)},
+		Position: getPos(c),
+	}
+	for _, value := range toAnySlice(rest) {
+		out.Array = append(out.Array, toAnySlice(value)[3].(ast.Node))
+	}
+	return out, nil
+}
+
+func (p *parser) callonForInExpr5() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr5(stack["first"], stack["rest"])
+}
+
+func (c *current) onForInExpr34() (any, error) {
+
+	return ast.Ident{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForInExpr34() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr34()
+}
+
+func (c *current) onForInExpr32(name any) (any, error) {
+	return ast.Value{Node: name.(ast.Node)}, nil
+}
+
+func (p *parser) callonForInExpr32() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr32(stack["name"])
+}
+
+func (c *current) onForInExpr42() (any, error) {
+	return ast.Operator{
+		Value:    string(c.text),
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForInExpr42() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr42()
+}
+
+func (c *current) onForInExpr1(first, op, value any) (any, error) {
+	return ast.Expr{
+		First: first.(ast.Node),
+		Rest: []ast.Expr{{
+			Operator: op.(ast.Operator),
+			First:    value.(ast.Node),
+			Position: value.(ast.Node).Pos(),
+		}},
+		Position: getPos(c),
+	}, nil
+}
+
+func (p *parser) callonForInExpr1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onForInExpr1(stack["first"], stack["op"], stack["value"])
+}
+
 func (c *current) onExprTag1(ignoreErr, item any) (any, error) {
 	return ast.ExprTag{
 		Value:       item.(ast.Node),
@@ -2427,7 +3229,7 @@ var (
 
 	// errMaxExprCnt is used to signal that the maximum number of
 	// expressions have been parsed.
-	errMaxExprCnt = errors.New("max number of expresssions parsed")
+	errMaxExprCnt = errors.New("max number of expressions parsed")
 )
 
 // Option is a function that can set an option on the parser. It returns
@@ -0,0 +1,71 @@
+package salix
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeCache caches reflection metadata, such as field and method indices,
+// so that it doesn't have to be looked up again every time a template uses it.
+// It's shared by all the templates in a namespace.
+type typeCache struct {
+	fields  sync.Map // map[memberKey][]int
+	methods sync.Map // map[memberKey]int
+	funcs   sync.Map // map[reflect.Type]error
+}
+
+// memberKey identifies a field or method of a type
+type memberKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// fieldIndex returns the index sequence of the struct field with the given name
+// in typ, which can be used with reflect.Value.FieldByIndex. If there's no such
+// field, it returns false.
+func (tc *typeCache) fieldIndex(typ reflect.Type, name string) ([]int, bool) {
+	key := memberKey{typ, name}
+	if index, ok := tc.fields.Load(key); ok {
+		return index.([]int), index.([]int) != nil
+	}
+
+	var index []int
+	if field, ok := typ.FieldByName(name); ok {
+		index = field.Index
+	}
+	tc.fields.Store(key, index)
+	return index, index != nil
+}
+
+// methodIndex returns the index of the method with the given name in typ,
+// which can be used with reflect.Value.Method. If there's no such method,
+// it returns false.
+func (tc *typeCache) methodIndex(typ reflect.Type, name string) (int, bool) {
+	key := memberKey{typ, name}
+	if index, ok := tc.methods.Load(key); ok {
+		return index.(int), index.(int) >= 0
+	}
+
+	index := -1
+	if method, ok := typ.MethodByName(name); ok {
+		index = method.Index
+	}
+	tc.methods.Store(key, index)
+	return index, index >= 0
+}
+
+// checkFunc returns the result of checkFuncType for typ,
+// caching it so that it only has to be checked once.
+func (tc *typeCache) checkFunc(typ reflect.Type) error {
+	if err, ok := tc.funcs.Load(typ); ok {
+		// A nil error can't be type-asserted, so check for it first
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+
+	err := checkFuncType(typ)
+	tc.funcs.Store(typ, err)
+	return err
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"html"
 	"io"
+	"log"
 	"reflect"
 	"strconv"
 
@@ -20,6 +21,7 @@ type HTML string
 type Template struct {
 	ns   *Namespace
 	name string
+	src  []byte
 	ast  []ast.Node
 
 	escapeHTML *bool
@@ -30,7 +32,9 @@ type Template struct {
 
 	tags   map[string]Tag
 	vars   map[string]any
-	macros map[string][]ast.Node
+	macros map[string]macro
+	blocks map[string][][]ast.Node
+	lazy   map[*LazyValue]lazyResult
 }
 
 // WithVarMap returns a copy of the template with its variable map set to m.
@@ -76,10 +80,16 @@ func (t Template) WithNilToZero(b bool) Template {
 // Execute executes a parsed template and writes
 // the result to w.
 func (t Template) Execute(w io.Writer) error {
-	t.macros = map[string][]ast.Node{}
+	if err := t.checkVarMaps(); err != nil {
+		return err
+	}
+
+	t.macros = map[string]macro{}
+	t.blocks = map[string][][]ast.Node{}
+	t.lazy = map[*LazyValue]lazyResult{}
 	if t.WriteOnSuccess {
 		buf := &bytes.Buffer{}
-		err := t.execute(buf, t.ast, nil)
+		err := t.executeRoot(buf)
 		if err != nil {
 			return err
 		}
@@ -88,13 +98,39 @@ func (t Template) Execute(w io.Writer) error {
 	} else {
 		bw := bufio.NewWriterSize(w, 16384)
 		defer bw.Flush()
-		return t.execute(bw, t.ast, nil)
+		return t.executeRoot(bw)
+	}
+}
+
+// executeRoot executes the template's AST, or the AST of its base template if
+// it extends another one. Break and continue signals that weren't handled by
+// any loop are converted into regular errors.
+func (t *Template) executeRoot(w io.Writer) error {
+	nodes, err := t.resolveInheritance(t.name, t.ast)
+	if err != nil {
+		return nodeToError(err)
+	}
+
+	err = t.execute(w, nodes, nil)
+	if isLoopSignal(err) {
+		return nodeToError(err)
+	}
+	return err
+}
+
+// nodeToError converts err into an *Error using the node it refers to.
+// If it doesn't refer to a node, it's returned as-is.
+func nodeToError(err error) error {
+	var nerr *ast.NodeError
+	if errors.As(err, &nerr) {
+		return toError(nerr.Node, err, nil)
 	}
+	return err
 }
 
-func (t *Template) execute(w io.Writer, nodes []ast.Node, local map[string]any) error {
+func (t *Template) execute(w io.Writer, nodes []ast.Node, local *scope) error {
 	if local == nil {
-		local = map[string]any{}
+		local = newScope(nil, nil)
 	}
 
 	for i := 0; i < len(nodes); i++ {
@@ -102,12 +138,14 @@ func (t *Template) execute(w io.Writer, nodes []ast.Node, local map[string]any)
 		case ast.Text:
 			_, err := w.Write(node.Data)
 			if err != nil {
-				return ast.PosError(node, "%w", err)
+				return toError(node, err, local)
 			}
 		case ast.Tag:
 			newOffset, err := t.execTag(node, w, nodes, i, local)
-			if err != nil {
+			if isLoopSignal(err) {
 				return err
+			} else if err != nil {
+				return toError(node, err, local)
 			}
 			i = newOffset
 		case ast.EndTag:
@@ -115,14 +153,15 @@ func (t *Template) execute(w io.Writer, nodes []ast.Node, local map[string]any)
 			// should be taken care of by execTag, so if we do,
 			// return an error because execTag was never called,
 			// which means there was no start tag.
-			return ast.PosError(node, "end tag without a matching start tag: %s", node.Name.Value)
+			return toError(node, ast.PosError(node, "end tag without a matching start tag: %s", node.Name.Value), local)
 		case ast.ExprTag:
 			v, err := t.getValue(node.Value, local)
 			if err != nil {
 				if node.IgnoreError {
+					t.warn(node, err, local)
 					continue
 				} else {
-					return err
+					return toError(node, err, local)
 				}
 			}
 			if _, ok := v.(ast.Assignment); ok {
@@ -137,7 +176,7 @@ func (t *Template) execute(w io.Writer, nodes []ast.Node, local map[string]any)
 			}
 			_, err = io.WriteString(w, t.toString(v))
 			if err != nil {
-				return err
+				return toError(node, err, local)
 			}
 		}
 	}
@@ -147,8 +186,8 @@ func (t *Template) execute(w io.Writer, nodes []ast.Node, local map[string]any)
 func (t *Template) getEscapeHTML() bool {
 	if t.escapeHTML != nil {
 		return *t.escapeHTML
-	} else if t.ns.escapeHTML != nil {
-		return *t.ns.getEscapeHTML()
+	} else if escapeHTML := t.ns.getEscapeHTML(); escapeHTML != nil {
+		return *escapeHTML
 	} else {
 		return false
 	}
@@ -158,6 +197,63 @@ func (t *Template) getNilToZero() bool {
 	return t.NilToZero || t.ns.NilToZero
 }
 
+// warn reports a warning to the namespace's warning handler
+// if strict mode is enabled.
+func (t *Template) warn(node ast.Node, err error, local *scope) {
+	if !t.ns.Strict {
+		return
+	}
+
+	w := toError(node, err, local)
+	if t.ns.WarningHandler != nil {
+		t.ns.WarningHandler(w)
+	} else {
+		log.Println("salix: warning:", w)
+	}
+}
+
+// checkShadow returns an error if strict mode is enabled
+// and name shadows a global function.
+func (t *Template) checkShadow(node ast.Node, name string) error {
+	if !t.ns.Strict {
+		return nil
+	}
+	if _, ok := globalVars[name]; ok {
+		return ast.PosError(node, "variable %s shadows a global function", name)
+	}
+	return nil
+}
+
+// checkVarMaps returns an error if strict mode is enabled and any of the
+// variables in the template or namespace variable maps shadow a global function.
+func (t *Template) checkVarMaps() error {
+	if !t.ns.Strict {
+		return nil
+	}
+
+	for name := range t.vars {
+		if _, ok := globalVars[name]; ok {
+			return &Error{
+				Position: ast.Position{Name: t.name},
+				Source:   name,
+				Err:      fmt.Errorf("variable %s shadows a global function", name),
+			}
+		}
+	}
+
+	for name := range t.ns.getVars() {
+		if _, ok := globalVars[name]; ok {
+			return &Error{
+				Position: ast.Position{Name: t.name},
+				Source:   name,
+				Err:      fmt.Errorf("namespace variable %s shadows a global function", name),
+			}
+		}
+	}
+
+	return nil
+}
+
 func (t *Template) toString(v any) string {
 	if h, ok := v.(HTML); ok {
 		return string(h)
@@ -201,7 +297,7 @@ func (t *Template) getBlock(nodes []ast.Node, offset, startLine int, name string
 }
 
 // getValue gets a Go value from an AST node
-func (t *Template) getValue(node ast.Node, local map[string]any) (any, error) {
+func (t *Template) getValue(node ast.Node, local *scope) (any, error) {
 	switch node := node.(type) {
 	case ast.Value:
 		return t.unwrapASTValue(node, local)
@@ -341,7 +437,7 @@ func getOneMapPair(m ast.Map) (k, v ast.Node) {
 }
 
 // unwrapASTValue unwraps an ast.Value node into its underlying value
-func (t *Template) unwrapASTValue(node ast.Value, local map[string]any) (any, error) {
+func (t *Template) unwrapASTValue(node ast.Value, local *scope) (any, error) {
 	v, err := t.getValue(node.Node, local)
 	if err != nil {
 		return nil, err
@@ -350,14 +446,16 @@ func (t *Template) unwrapASTValue(node ast.Value, local map[string]any) (any, er
 	rval := reflect.ValueOf(v)
 
 	if node.Not {
-		if rval.Kind() != reflect.Bool {
+		cond, ok := t.isTrue(rval)
+		if !ok {
 			return nil, ast.PosError(node, "%s: the ! operator can only be used on boolean values", valueToString(node))
 		}
-		return !rval.Bool(), nil
+		return !cond, nil
 	}
 
 	if rval.Kind() == reflect.Pointer && rval.IsNil() && t.getNilToZero() {
 		rtyp := rval.Type().Elem()
+		t.warn(node, ast.PosError(node, "%s: nil value converted to zero value of %s", valueToString(node), rtyp), nil)
 		return reflect.New(rtyp).Interface(), nil
 	}
 
@@ -366,7 +464,7 @@ func (t *Template) unwrapASTValue(node ast.Value, local map[string]any) (any, er
 
 // convertMap converts an ast.Map value into a map[any]any by recursively calling
 // getValue on each of its keys and values.
-func (t *Template) convertMap(node ast.Map, local map[string]any) (any, error) {
+func (t *Template) convertMap(node ast.Map, local *scope) (any, error) {
 	out := make(map[any]any, len(node.Map))
 	for keyNode, valNode := range node.Map {
 		key, err := t.getValue(keyNode, local)
@@ -386,7 +484,7 @@ func (t *Template) convertMap(node ast.Map, local map[string]any) (any, error) {
 
 // convertArray converts an ast.Array into an []any by recursively calling getValue
 // on each of its elements.
-func (t *Template) convertArray(node ast.Array, local map[string]any) (any, error) {
+func (t *Template) convertArray(node ast.Array, local *scope) (any, error) {
 	out := make([]any, len(node.Array))
 	for i, valNode := range node.Array {
 		val, err := t.getValue(valNode, local)
@@ -398,33 +496,61 @@ func (t *Template) convertArray(node ast.Array, local map[string]any) (any, erro
 	return out, nil
 }
 
-// getVar tries to get a variable from the local map. If it's not found,
-// it'll try the global variable map. If it doesn't exist in either map,
-// it will return an error.
-func (t *Template) getVar(id ast.Ident, local map[string]any) (any, error) {
-	if local != nil {
-		v, ok := local[id.Value]
-		if ok {
-			return v, nil
-		}
+// getVar tries to get a variable from the local scope. If it's not found,
+// it'll try the template, namespace, and global variable maps. If it doesn't
+// exist in any of them, it calls the namespace's undefined variable handler
+// if there is one, and otherwise returns an error.
+func (t *Template) getVar(id ast.Ident, local *scope) (any, error) {
+	v, ok, err := t.lookupVar(id, local)
+	if ok || err != nil {
+		return v, err
 	}
 
-	v, ok := t.vars[id.Value]
-	if ok {
-		return v, nil
+	v, ok, err = t.handleUndefinedVar(id)
+	if ok || err != nil {
+		return v, err
 	}
 
-	v, ok = t.ns.getVar(id.Value)
+	return reflect.Value{}, ast.PosError(id, "no such variable: %s", id.Value)
+}
+
+// lookupVar tries to get a variable from the local scope, and the template,
+// namespace, and global variable maps, without calling the undefined variable
+// handler. If the variable doesn't exist in any of them, it returns false.
+func (t *Template) lookupVar(id ast.Ident, local *scope) (any, bool, error) {
+	v, ok := local.get(id.Value)
+	if !ok {
+		v, ok = t.vars[id.Value]
+	}
+	if !ok {
+		v, ok = t.ns.getVar(id.Value)
+	}
 	if ok {
-		return v, nil
+		v, err := t.resolveLazy(id, v)
+		return v, true, err
 	}
 
 	v, ok = globalVars[id.Value]
-	if ok {
-		return v, nil
+	return v, ok, nil
+}
+
+// handleUndefinedVar calls the namespace's undefined variable handler
+// for id, if there is one. If there isn't, it returns false.
+func (t *Template) handleUndefinedVar(id ast.Ident) (any, bool, error) {
+	handler := t.ns.UndefinedVarHandler
+	if handler == nil {
+		return nil, false, nil
 	}
 
-	return reflect.Value{}, ast.PosError(id, "no such variable: %s", id.Value)
+	v, ok, err := handler(id.Value, id.Position)
+	if err != nil {
+		return nil, false, ast.PosError(id, "%s: %w", id.Value, err)
+	} else if !ok {
+		return nil, false, nil
+	}
+
+	v, err = t.resolveLazy(id, v)
+	return v, true, err
 }
 
 func (t *Template) getTag(name string) (Tag, bool) {
@@ -447,7 +573,7 @@ func (t *Template) getTag(name string) (Tag, bool) {
 }
 
 // execTag executes a tag
-func (t *Template) execTag(node ast.Tag, w io.Writer, nodes []ast.Node, i int, local map[string]any) (newOffset int, err error) {
+func (t *Template) execTag(node ast.Tag, w io.Writer, nodes []ast.Node, i int, local *scope) (newOffset int, err error) {
 	tag, ok := t.getTag(node.Name.Value)
 	if !ok {
 		return 0, ast.PosError(node, "no such tag: %s", node.Name.Value)
@@ -462,24 +588,91 @@ func (t *Template) execTag(node ast.Tag, w io.Writer, nodes []ast.Node, i int, l
 	tc := &TagContext{node, w, t, local}
 
 	err = tag.Run(tc, block, node.Params)
-	if err != nil {
-		return 0, errors.Join(ast.PosError(node, "%s ->", valueToString(node)), err)
+	if isLoopSignal(err) {
+		return 0, err
+	} else if err != nil {
+		return 0, pushFrame(node, err)
 	}
 
 	return i, nil
 }
 
 // execFuncCall executes a function call
-func (t *Template) execFuncCall(fc ast.FuncCall, local map[string]any) (any, error) {
-	fn, err := t.getVar(fc.Name, local)
+func (t *Template) execFuncCall(fc ast.FuncCall, local *scope) (any, error) {
+	fn, ok, err := t.lookupVar(fc.Name, local)
 	if err != nil {
-		return nil, ast.PosError(fc, "no such function: %s", fc.Name.Value)
+		return nil, err
+	}
+
+	if !ok {
+		// Variables take precedence over macros, but macros take precedence
+		// over the undefined variable handler, so that it isn't called for them.
+		if m, ok := t.macros[fc.Name.Value]; ok {
+			return t.execMacroCall(fc, fc.Name.Value, m, fc.Params, local)
+		} else if fc.Name.Value == "macro" {
+			return t.execMacroFunc(fc, local)
+		}
+
+		fn, ok, err = t.handleUndefinedVar(fc.Name)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, ast.PosError(fc, "no such function: %s", fc.Name.Value)
+		}
 	}
+
 	return t.execFunc(reflect.ValueOf(fn), fc, fc.Params, local)
 }
 
+// execMacroFunc executes a call to the macro function, which calls
+// the macro named by its first argument with the rest of its arguments.
+func (t *Template) execMacroFunc(fc ast.FuncCall, local *scope) (any, error) {
+	if len(fc.Params) < 1 {
+		return nil, ast.PosError(fc, "macro expects at least one argument, got %d", len(fc.Params))
+	}
+
+	nameVal, err := t.getValue(fc.Params[0], local)
+	if err != nil {
+		return nil, err
+	}
+
+	name, ok := nameVal.(string)
+	if !ok {
+		return nil, ast.PosError(fc.Params[0], "invalid first argument type: %T (expected string)", nameVal)
+	}
+
+	m, ok := t.macros[name]
+	if !ok {
+		return nil, ast.PosError(fc, "no such macro: %q", name)
+	}
+	return t.execMacroCall(fc, name, m, fc.Params[1:], local)
+}
+
+// execMacroCall executes the macro m with the given arguments,
+// and returns its output as HTML.
+func (t *Template) execMacroCall(fc ast.FuncCall, name string, m macro, args []ast.Node, local *scope) (any, error) {
+	vars, err := t.bindMacroArgs(fc, name, m, args, local)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &TagContext{
+		Tag:   ast.Tag{Name: fc.Name, Params: fc.Params, Position: fc.Position},
+		t:     t,
+		local: local,
+	}
+
+	// Any values in the macro have already been escaped when they were
+	// written, so the output is returned as HTML to avoid escaping it twice.
+	out, err := tc.ExecuteToMemory(m.block, vars)
+	if err != nil {
+		return nil, pushFrame(fc, err)
+	}
+	return HTML(out), nil
+}
+
 // getIndex tries to evaluate an ast.Index node by indexing the underlying value.
-func (t *Template) getIndex(i ast.Index, local map[string]any) (any, error) {
+func (t *Template) getIndex(i ast.Index, local *scope) (any, error) {
 	val, err := t.getValue(i.Value, local)
 	if err != nil {
 		return nil, err
@@ -539,7 +732,7 @@ func (t *Template) getIndex(i ast.Index, local map[string]any) (any, error) {
 }
 
 // getField tries to get a struct field from the underlying value
-func (t *Template) getField(fa ast.FieldAccess, local map[string]any) (any, error) {
+func (t *Template) getField(fa ast.FieldAccess, local *scope) (any, error) {
 	val, err := t.getValue(fa.Value, local)
 	if err != nil {
 		return nil, err
@@ -554,15 +747,19 @@ func (t *Template) getField(fa ast.FieldAccess, local map[string]any) (any, erro
 	if rval.Kind() != reflect.Struct || rval.NumField() == 0 {
 		return nil, ast.PosError(fa, "%s: value has no fields", valueToString(fa))
 	}
-	field := rval.FieldByName(fa.Name.Value)
-	if !field.IsValid() {
+	index, ok := t.ns.types.fieldIndex(rval.Type(), fa.Name.Value)
+	if !ok {
 		return nil, ast.PosError(fa, "%s: no such field: %s", valueToString(fa), fa.Name.Value)
 	}
+	field, err := rval.FieldByIndexErr(index)
+	if err != nil {
+		return nil, ast.PosError(fa, "%s: %w", valueToString(fa), err)
+	}
 	return field.Interface(), nil
 }
 
 // execMethodCall executes a method call on the underlying value
-func (t *Template) execMethodCall(mc ast.MethodCall, local map[string]any) (any, error) {
+func (t *Template) execMethodCall(mc ast.MethodCall, local *scope) (any, error) {
 	val, err := t.getValue(mc.Value, local)
 	if err != nil {
 		return nil, err
@@ -572,9 +769,8 @@ func (t *Template) execMethodCall(mc ast.MethodCall, local map[string]any) (any,
 		return nil, ast.PosError(mc, "%s: cannot call method on nil value", valueToString(mc))
 	}
 	// First, check for a method with the given name
-	mtd := rval.MethodByName(mc.Name.Value)
-	if mtd.IsValid() {
-		return t.execFunc(mtd, mc, mc.Params, local)
+	if index, ok := t.ns.types.methodIndex(rval.Type(), mc.Name.Value); ok {
+		return t.execFunc(rval.Method(index), mc, mc.Params, local)
 	}
 	// If the method doesn't exist, we need to check for fields, so dereference any pointers
 	// because pointers can't have fields
@@ -584,9 +780,11 @@ func (t *Template) execMethodCall(mc ast.MethodCall, local map[string]any) (any,
 	// Make sure we actually have a struct
 	if rval.Kind() == reflect.Struct {
 		// If the method doesn't exist, also check for a field storing a function.
-		field := rval.FieldByName(mc.Name.Value)
-		if field.IsValid() && field.Kind() == reflect.Func {
-			return t.execFunc(field, mc, mc.Params, local)
+		if index, ok := t.ns.types.fieldIndex(rval.Type(), mc.Name.Value); ok {
+			field, err := rval.FieldByIndexErr(index)
+			if err == nil && field.Kind() == reflect.Func {
+				return t.execFunc(field, mc, mc.Params, local)
+			}
 		}
 	}
 	// If neither of those exist, return an error
@@ -594,7 +792,7 @@ func (t *Template) execMethodCall(mc ast.MethodCall, local map[string]any) (any,
 }
 
 // execFunc executes a function call
-func (t *Template) execFunc(fn reflect.Value, node ast.Node, args []ast.Node, local map[string]any) (any, error) {
+func (t *Template) execFunc(fn reflect.Value, node ast.Node, args []ast.Node, local *scope) (any, error) {
 	if !fn.IsValid() {
 		return nil, ast.PosError(node, "%s: cannot call nil function", valueToString(node))
 	}
@@ -607,8 +805,8 @@ func (t *Template) execFunc(fn reflect.Value, node ast.Node, args []ast.Node, lo
 		return nil, ast.PosError(node, "%s: invalid parameter amount: %d (expected %d)", valueToString(node), len(args), fnType.NumIn())
 	}
 
-	if err := validateFunc(fnType, node); err != nil {
-		return nil, err
+	if err := t.ns.types.checkFunc(fnType); err != nil {
+		return nil, ast.PosError(node, "%w", err)
 	}
 
 	params := make([]reflect.Value, 0, fnType.NumIn())
@@ -650,13 +848,13 @@ func (t *Template) execFunc(fn reflect.Value, node ast.Node, args []ast.Node, lo
 	}
 }
 
-func (t *Template) evalTernary(tr ast.Ternary, local map[string]any) (any, error) {
+func (t *Template) evalTernary(tr ast.Ternary, local *scope) (any, error) {
 	condVal, err := t.getValue(tr.Condition, local)
 	if err != nil {
 		return nil, err
 	}
 
-	cond, ok := condVal.(bool)
+	cond, ok := t.isTrue(reflect.ValueOf(condVal))
 	if !ok {
 		return nil, ast.PosError(tr.Condition, "%s: ternary condition must be a boolean value", valueToString(tr.Condition))
 	}
@@ -668,7 +866,7 @@ func (t *Template) evalTernary(tr ast.Ternary, local map[string]any) (any, error
 	}
 }
 
-func (t *Template) evalVariableOr(vo ast.VariableOr, local map[string]any) (any, error) {
+func (t *Template) evalVariableOr(vo ast.VariableOr, local *scope) (any, error) {
 	val, err := t.getVar(vo.Variable, local)
 	if err != nil {
 		return t.getValue(vo.Or, local)
@@ -676,26 +874,30 @@ func (t *Template) evalVariableOr(vo ast.VariableOr, local map[string]any) (any,
 	return val, nil
 }
 
-func (t *Template) handleAssignment(a ast.Assignment, local map[string]any) error {
+func (t *Template) handleAssignment(a ast.Assignment, local *scope) error {
+	if err := t.checkShadow(a.Name, a.Name.Value); err != nil {
+		return err
+	}
 	val, err := t.getValue(a.Value, local)
 	if err != nil {
 		return err
 	}
-	local[a.Name.Value] = val
+	local.define(a.Name.Value, val)
 	return nil
 }
 
-func validateFunc(t reflect.Type, node ast.Node) error {
+// checkFuncType checks whether t is a valid template function type
+func checkFuncType(t reflect.Type) error {
 	numOut := t.NumOut()
 	if numOut > 2 {
-		return ast.PosError(node, "template functions cannot have more than two return values")
+		return errors.New("template functions cannot have more than two return values")
 	} else if numOut == 0 {
-		return ast.PosError(node, "template functions must have at least one return value")
+		return errors.New("template functions must have at least one return value")
 	}
 	if numOut == 2 {
 		errType := reflect.TypeOf((*error)(nil)).Elem()
 		if !t.Out(1).Implements(errType) {
-			return ast.PosError(node, "the second return value of a template function must be an error")
+			return errors.New("the second return value of a template function must be an error")
 		}
 	}
 
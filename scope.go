@@ -0,0 +1,64 @@
+package salix
+
+// scope represents a level of local variables. Scopes form a chain:
+// the block of every tag is executed in a new scope whose parent is
+// the scope that the tag itself was executed in, so variables defined
+// within a block are discarded once it's done executing.
+type scope struct {
+	vars   map[string]any
+	parent *scope
+}
+
+// newScope returns a new scope with the given parent, containing vars.
+// The vars map is owned by the scope after it's passed to newScope.
+func newScope(parent *scope, vars map[string]any) *scope {
+	if vars == nil {
+		vars = map[string]any{}
+	}
+	return &scope{vars: vars, parent: parent}
+}
+
+// get looks up the variable with the given name in s
+// and its parents, starting with the innermost scope.
+func (s *scope) get(name string) (any, bool) {
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// define sets the variable with the given name in s itself,
+// shadowing any variables with the same name in its parents.
+func (s *scope) define(name string, val any) {
+	s.vars[name] = val
+}
+
+// set updates the variable with the given name in the innermost scope
+// that defines it. If no scope defines it, it's defined in the root scope.
+func (s *scope) set(name string, val any) {
+	cur := s
+	for ; cur.parent != nil; cur = cur.parent {
+		if _, ok := cur.vars[name]; ok {
+			break
+		}
+	}
+	cur.vars[name] = val
+}
+
+// flatten returns a map containing all the variables that are visible
+// from s, with inner scopes taking precedence over outer ones.
+func (s *scope) flatten() map[string]any {
+	if s == nil {
+		return nil
+	}
+	out := s.parent.flatten()
+	if out == nil {
+		out = make(map[string]any, len(s.vars))
+	}
+	for k, v := range s.vars {
+		out[k] = v
+	}
+	return out
+}
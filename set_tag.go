@@ -0,0 +1,56 @@
+package salix
+
+import "go.elara.ws/salix/ast"
+
+// setTag represents a #set tag within a Salix template
+type setTag struct{}
+
+func (st setTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if tc.Tag.HasBody {
+		return tc.PosError(tc.Tag, "set tags cannot have a body")
+	}
+
+	return evalAssignments(tc, args, tc.local, tc.local.set)
+}
+
+// letTag represents a #let tag within a Salix template
+type letTag struct{}
+
+func (lt letTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if !tc.Tag.HasBody {
+		return evalAssignments(tc, args, tc.local, tc.local.define)
+	}
+
+	local := newScope(tc.local, nil)
+	if err := evalAssignments(tc, args, local, local.define); err != nil {
+		return err
+	}
+	return tc.t.execute(tc.w, block, local)
+}
+
+// evalAssignments evaluates the assignments in args in order using the local scope,
+// and calls assign with the name and value of each one.
+func evalAssignments(tc *TagContext, args []ast.Node, local *scope, assign func(name string, val any)) error {
+	if len(args) == 0 {
+		return tc.PosError(tc.Tag, "expected at least one argument, got 0")
+	}
+
+	for _, arg := range args {
+		a, ok := arg.(ast.Assignment)
+		if !ok {
+			return tc.PosError(arg, "%s: invalid argument type: %T (expected ast.Assignment)", tc.NodeToString(arg), arg)
+		}
+
+		if err := tc.t.checkShadow(a.Name, a.Name.Value); err != nil {
+			return err
+		}
+
+		val, err := tc.t.getValue(a.Value, local)
+		if err != nil {
+			return err
+		}
+		assign(a.Name.Value, val)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package salix
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStrictIgnoredError(t *testing.T) {
+	var warnings []*Error
+	ns := New().WithStrict(true).WithWarningHandler(func(w *Error) {
+		warnings = append(warnings, w)
+	})
+
+	tmpl, err := ns.ParseString("test", `#?(x.Y)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(warnings))
+	}
+
+	if !strings.Contains(warnings[0].Error(), "no such variable: x") {
+		t.Errorf("Unexpected warning: %s", warnings[0])
+	}
+}
+
+func TestStrictNilToZero(t *testing.T) {
+	type user struct{ Name string }
+
+	var warnings []*Error
+	ns := New().WithStrict(true).WithNilToZero(true).WithWarningHandler(func(w *Error) {
+		warnings = append(warnings, w)
+	})
+
+	tmpl, err := ns.ParseString("test", `#(user.Name)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"user": (*user)(nil)}).Execute(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %d", len(warnings))
+	}
+}
+
+func TestStrictShadowAssignment(t *testing.T) {
+	tmpl, err := New().WithStrict(true).ParseString("test", `#(len = 1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestStrictShadowLoopVar(t *testing.T) {
+	tmpl, err := New().WithStrict(true).ParseString("test", `#for(json in items):#!for`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"items": []int{1}}).Execute(io.Discard)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func TestStrictShadowVarMap(t *testing.T) {
+	tmpl, err := New().WithStrict(true).ParseString("test", `#(toUpper)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"toUpper": "x"}).Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	var serr *Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("Expected *Error, got %T", err)
+	}
+
+	if serr.Position.Name != "test" {
+		t.Errorf("Expected position name %q, got %q", "test", serr.Position.Name)
+	}
+}
+
+func TestStrictShadowNamespaceVar(t *testing.T) {
+	ns := New().WithStrict(true).WithVarMap(map[string]any{"len": 1})
+	tmpl, err := ns.ParseString("test", `#(1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+
+	var serr *Error
+	if !errors.As(err, &serr) {
+		t.Fatalf("Expected *Error, got %T", err)
+	}
+
+	if serr.Position.Name != "test" {
+		t.Errorf("Expected position name %q, got %q", "test", serr.Position.Name)
+	}
+}
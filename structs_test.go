@@ -2,6 +2,7 @@ package salix
 
 import (
 	"fmt"
+	"io"
 	"testing"
 	"time"
 
@@ -21,7 +22,7 @@ func TestSliceGetIndex(t *testing.T) {
 				Position: testPos(t),
 			}
 
-			val, err := tmpl.getIndex(ast, map[string]any{"test": testSlice, "index": index})
+			val, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testSlice, "index": index}))
 			if err != nil {
 				t.Fatalf("getIndex error: %s", err)
 			}
@@ -45,7 +46,7 @@ func TestSliceGetNegativeIndex(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	val, err := tmpl.getIndex(ast, map[string]any{"test": testSlice})
+	val, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testSlice}))
 	if err != nil {
 		t.Fatalf("getIndex error: %s", err)
 	}
@@ -66,7 +67,7 @@ func TestSliceGetIndexOutOfRange(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testSlice})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testSlice}))
 	if err == nil {
 		t.Errorf("Expected error, got nil")
 	}
@@ -83,7 +84,7 @@ func TestSliceGetNegativeIndexOutOfRange(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testSlice})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testSlice}))
 	if err == nil {
 		t.Errorf("Expected error, got nil")
 	}
@@ -100,7 +101,7 @@ func TestSliceGetIndexInvalidType(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testSlice})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testSlice}))
 	if err == nil {
 		t.Errorf("Expected error, got nil")
 	}
@@ -119,7 +120,7 @@ func TestMapGetIndex(t *testing.T) {
 				Position: testPos(t),
 			}
 
-			val, err := tmpl.getIndex(ast, map[string]any{"test": testMap, "index": index})
+			val, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testMap, "index": index}))
 			if err != nil {
 				t.Fatalf("getIndex error: %s", err)
 			}
@@ -142,7 +143,7 @@ func TestMapGetIndexNotFound(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testMap})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testMap}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -159,7 +160,7 @@ func TestMapGetIndexInvalidType(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testMap})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testMap}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -175,7 +176,7 @@ func TestGetIndexNil(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": nil})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": nil}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -192,7 +193,7 @@ func TestGetIndexNilIndex(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testMap, "index": nil})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testMap, "index": nil}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -209,7 +210,7 @@ func TestGetIndexInvalidContainer(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getIndex(ast, map[string]any{"test": testStruct})
+	_, err := tmpl.getIndex(ast, newScope(nil, map[string]any{"test": testStruct}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -242,7 +243,7 @@ func TestGetField(t *testing.T) {
 				Position: testPos(t),
 			}
 
-			val, err := tmpl.getField(ast, map[string]any{"test": testStruct})
+			val, err := tmpl.getField(ast, newScope(nil, map[string]any{"test": testStruct}))
 			if err != nil {
 				t.Fatalf("getField error: %s", err)
 			}
@@ -264,7 +265,7 @@ func TestGetFieldNil(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getField(ast, map[string]any{"test": nil})
+	_, err := tmpl.getField(ast, newScope(nil, map[string]any{"test": nil}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -281,7 +282,7 @@ func TestGetFieldNoFields(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getField(ast, map[string]any{"test": testStruct})
+	_, err := tmpl.getField(ast, newScope(nil, map[string]any{"test": testStruct}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -298,7 +299,7 @@ func TestGetFieldNotFound(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.getField(ast, map[string]any{"test": testStruct})
+	_, err := tmpl.getField(ast, newScope(nil, map[string]any{"test": testStruct}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -324,7 +325,7 @@ func TestMethodCall(t *testing.T) {
 	}
 
 	tmpl := testTmpl(t)
-	val, err := tmpl.execMethodCall(ast, map[string]any{"test": testStruct})
+	val, err := tmpl.execMethodCall(ast, newScope(nil, map[string]any{"test": testStruct}))
 	if err != nil {
 		t.Fatalf("execMethodCall error: %s", err)
 	}
@@ -349,7 +350,7 @@ func TestMethodCallTime(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	val, err := tmpl.execMethodCall(ast, map[string]any{"t": testStruct})
+	val, err := tmpl.execMethodCall(ast, newScope(nil, map[string]any{"t": testStruct}))
 	if err != nil {
 		t.Fatalf("execMethodCall error: %s", err)
 	}
@@ -372,7 +373,7 @@ func TestMethodCallNotFound(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.execMethodCall(ast, map[string]any{"test": struct{}{}})
+	_, err := tmpl.execMethodCall(ast, newScope(nil, map[string]any{"test": struct{}{}}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -391,8 +392,76 @@ func TestMethodCallNil(t *testing.T) {
 		Position: testPos(t),
 	}
 
-	_, err := tmpl.execMethodCall(ast, map[string]any{"test": nil})
+	_, err := tmpl.execMethodCall(ast, newScope(nil, map[string]any{"test": nil}))
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
 }
+
+type cacheTestInner struct {
+	Name string
+}
+
+func (cti cacheTestInner) Greet(s string) string {
+	return s + ", " + cti.Name
+}
+
+type cacheTestOuter struct {
+	*cacheTestInner
+	ID int
+}
+
+func TestFieldCacheEmbedded(t *testing.T) {
+	items := []any{
+		cacheTestOuter{&cacheTestInner{"a"}, 1},
+		cacheTestInner{"b"},
+		cacheTestOuter{&cacheTestInner{"c"}, 3},
+	}
+
+	res := execStr(t, `#for(item in items):#(item.Name) #(item.Greet("hi"));#!for`, map[string]any{"items": items})
+	if res != "a hi, a;b hi, b;c hi, c;" {
+		t.Errorf("Expected %q, got %q", "a hi, a;b hi, b;c hi, c;", res)
+	}
+}
+
+func TestFieldCacheNilEmbedded(t *testing.T) {
+	tmpl, err := New().ParseString("test", `#(item.Name)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"item": cacheTestOuter{}}).Execute(io.Discard)
+	if err == nil {
+		t.Error("Expected error, got nil")
+	}
+}
+
+func BenchmarkNestedStructLoop(b *testing.B) {
+	type item struct {
+		Name  string
+		Price float64
+	}
+	type order struct {
+		ID    int
+		Items []item
+	}
+
+	orders := make([]order, 50)
+	for i := range orders {
+		orders[i] = order{ID: i, Items: make([]item, 20)}
+	}
+
+	tmpl, err := New().ParseString("bench", `#for(order in orders):#for(item in order.Items):#(order.ID)#(item.Name)#(item.Price)#(trimSpace(item.Name))#!for#!for`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	tmpl = tmpl.WithVarMap(map[string]any{"orders": orders})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := tmpl.Execute(io.Discard)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
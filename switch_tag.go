@@ -0,0 +1,97 @@
+package salix
+
+import (
+	"reflect"
+
+	"go.elara.ws/salix/ast"
+)
+
+// switchTag represents a #switch tag within a Salix template
+type switchTag struct{}
+
+func (st switchTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if len(args) != 1 {
+		return tc.PosError(tc.Tag, "expected one argument, got %d", len(args))
+	}
+
+	cases, defaultCase, err := st.findCases(tc, block)
+	if err != nil {
+		return err
+	}
+
+	// The subject is only evaluated once,
+	// no matter how many cases there are.
+	val, err := tc.GetValue(args[0], nil)
+	if err != nil {
+		return err
+	}
+	subject := reflect.ValueOf(val)
+
+	for _, c := range cases {
+		for _, arg := range c.tag.Params {
+			caseVal, err := tc.GetValue(arg, nil)
+			if err != nil {
+				return err
+			}
+
+			op := ast.Operator{Value: "==", Position: arg.Pos()}
+			result, err := tc.t.performOp(subject, reflect.ValueOf(caseVal), op)
+			if err != nil {
+				return err
+			}
+
+			if result == true {
+				return tc.Execute(c.block, nil)
+			}
+		}
+	}
+
+	if defaultCase != nil {
+		return tc.Execute(defaultCase, nil)
+	}
+
+	return nil
+}
+
+// switchCase represents a #case tag within a switch tag
+type switchCase struct {
+	tag   ast.Tag
+	block []ast.Node
+}
+
+// findCases finds the case and default tags in a block passed to the switch tag,
+// and returns the content that belongs to each of them. Any content before the
+// first case is ignored.
+func (st switchTag) findCases(tc *TagContext, block []ast.Node) ([]switchCase, []ast.Node, error) {
+	markers := findMarkers(block, "case", "default")
+
+	var cases []switchCase
+	var defaultCase []ast.Node
+	for i, index := range markers {
+		end := len(block)
+		if i < len(markers)-1 {
+			end = markers[i+1]
+		}
+		content := block[index+1 : end]
+
+		tag := block[index].(ast.Tag)
+		if tag.Name.Value == "default" {
+			if defaultCase != nil {
+				return nil, nil, tc.PosError(tag, "cannot have more than one default tag in a switch tag")
+			} else if len(tag.Params) != 0 {
+				return nil, nil, tc.PosError(tag, "default tags don't accept any arguments")
+			}
+			// Make sure the default case isn't nil even if it's empty,
+			// so that duplicates are detected.
+			defaultCase = append([]ast.Node{}, content...)
+			continue
+		}
+
+		if len(tag.Params) == 0 {
+			return nil, nil, tc.PosError(tag, "expected at least one argument, got 0")
+		}
+		cases = append(cases, switchCase{tag: tag, block: content})
+	}
+
+	return cases, defaultCase, nil
+}
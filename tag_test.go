@@ -1,6 +1,7 @@
 package salix
 
 import (
+	"io"
 	"strings"
 	"testing"
 )
@@ -88,3 +89,556 @@ func TestForTwoArgs(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expected, res)
 	}
 }
+
+func TestForMapSorted(t *testing.T) {
+	testCases := []struct {
+		in       any
+		expected string
+	}{
+		{map[string]int{"c": 3, "a": 1, "b": 2, "d": 4}, "a1 b2 c3 d4 "},
+		{map[int]string{10: "x", -1: "y", 2: "z"}, "-1y 2z 10x "},
+		{map[any]int{2.5: 1, 1: 2, uint(2): 3}, "12 23 2.51 "},
+		{map[version]int{{1, 10}: 1, {1, 2}: 2}, "1.22 1.101 "},
+		{map[any]int{"a": 1, 1: 2, true: 3}, "12 a1 true3 "},
+		{map[any]int{"1": 1, 1: 2, true: 3}, "12 11 true3 "},
+	}
+
+	for _, tc := range testCases {
+		// Execute several times to make sure the order doesn't change
+		for i := 0; i < 5; i++ {
+			res := execStr(t, `#for(k, v in m):#(k)#(v) #!for`, map[string]any{"m": tc.in})
+			if res != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, res)
+			}
+		}
+	}
+}
+
+func TestForMapSortedSamePrint(t *testing.T) {
+	type point struct{ X int }
+
+	// The keys all print the same way, so the order has to be decided some
+	// other way, but it still has to be the same every time.
+	m := map[any]int{&point{1}: 1, &point{1}: 2, &point{1}: 3, "x": 0}
+
+	expected := execStr(t, `#for(v in m):#(v)#!for`, map[string]any{"m": m})
+	if !strings.HasSuffix(expected, "0") {
+		t.Errorf("Expected the string key to be last, got %q", expected)
+	}
+
+	for i := 0; i < 50; i++ {
+		res := execStr(t, `#for(v in m):#(v)#!for`, map[string]any{"m": m})
+		if res != expected {
+			t.Fatalf("Expected %q, got %q", expected, res)
+		}
+	}
+}
+
+type orderedMapTest struct {
+	keys []string
+	vals map[string]int
+}
+
+func (omt orderedMapTest) Range(fn func(key, value any) bool) {
+	for _, key := range omt.keys {
+		if !fn(key, omt.vals[key]) {
+			return
+		}
+	}
+}
+
+func TestForOrderedMap(t *testing.T) {
+	om := orderedMapTest{
+		keys: []string{"z", "a", "m"},
+		vals: map[string]int{"z": 1, "a": 2, "m": 3},
+	}
+
+	res := execStr(t, `#for(i, k, v in m):#(i)#(k)#(v) #!for`, map[string]any{"m": om})
+	if res != "0z1 1a2 2m3 " {
+		t.Errorf("Expected %q, got %q", "0z1 1a2 2m3 ", res)
+	}
+}
+
+func TestForLoop(t *testing.T) {
+	const tmplStr = `#for(v in s):#(loop.Index1)/#(loop.Length)=#(v)#if(loop.First): first#!if#if(loop.Last): last#else:, #!if#!for`
+
+	res := execStr(t, tmplStr, map[string]any{"s": []string{"a", "b", "c"}})
+	const expected = "1/3=a first, 2/3=b, 3/3=c last"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForLoopRevIndex(t *testing.T) {
+	res := execStr(t, `#for(k, v in m):#(loop.Index)#(loop.RevIndex)#!for`, map[string]any{"m": map[string]int{"a": 1, "b": 2, "c": 3}})
+	if res != "021120" {
+		t.Errorf("Expected %q, got %q", "021120", res)
+	}
+}
+
+func TestForLoopParent(t *testing.T) {
+	const tmplStr = `#for(x in outer):#for(y in inner):#(loop.Parent.Index)#(loop.Index) #!for#!for`
+
+	res := execStr(t, tmplStr, map[string]any{"outer": []int{1, 2}, "inner": []int{1, 2}})
+	const expected = "00 01 10 11 "
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForLoopSnapshot(t *testing.T) {
+	const tmplStr = `#for(x in xs):#if(loop.First):#set(first = loop)#!if#!for#(first.Index)#(first.First)`
+
+	res := execStr(t, tmplStr, map[string]any{"xs": []int{1, 2, 3}})
+	if res != "0true" {
+		t.Errorf("Expected %q, got %q", "0true", res)
+	}
+}
+
+func TestBreakContinue(t *testing.T) {
+	const tmplStr = `#for(i in s):#if(i == 2):#continue#!if#break(i >= 4)#(i)#!for`
+
+	res := execStr(t, tmplStr, map[string]any{"s": []int{0, 1, 2, 3, 4, 5}})
+	if res != "013" {
+		t.Errorf("Expected %q, got %q", "013", res)
+	}
+}
+
+func TestBreakNested(t *testing.T) {
+	const tmplStr = `#for(x in s):#for(y in s):#break(y > x)#(x)#(y) #!for#!for`
+
+	res := execStr(t, tmplStr, map[string]any{"s": []int{0, 1}})
+	const expected = "00 10 11 "
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestBreakOutsideLoop(t *testing.T) {
+	tmpl, err := New().ParseString("test", `#break`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "break outside of loop") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestForEmpty(t *testing.T) {
+	const tmplStr = `#for(v in s):#(v)#empty:none#!for`
+
+	res := execStr(t, tmplStr, map[string]any{"s": []int{1, 2}})
+	if res != "12" {
+		t.Errorf("Expected %q, got %q", "12", res)
+	}
+
+	res = execStr(t, tmplStr, map[string]any{"s": []int{}})
+	if res != "none" {
+		t.Errorf("Expected %q, got %q", "none", res)
+	}
+
+	res = execStr(t, tmplStr, map[string]any{"s": nil})
+	if res != "none" {
+		t.Errorf("Expected %q, got %q", "none", res)
+	}
+}
+
+func TestForElseNested(t *testing.T) {
+	const tmplStr = `#if(show):#for(v in s):#(v)#else:empty#!for#else:hidden#!if`
+
+	res := execStr(t, tmplStr, map[string]any{"show": true, "s": map[string]int{}})
+	if res != "empty" {
+		t.Errorf("Expected %q, got %q", "empty", res)
+	}
+
+	res = execStr(t, tmplStr, map[string]any{"show": false, "s": map[string]int{}})
+	if res != "hidden" {
+		t.Errorf("Expected %q, got %q", "hidden", res)
+	}
+}
+
+func TestForInt(t *testing.T) {
+	res := execStr(t, `#for(i in n):#(i)#!for`, map[string]any{"n": 5})
+	if res != "01234" {
+		t.Errorf("Expected %q, got %q", "01234", res)
+	}
+}
+
+func TestForRange(t *testing.T) {
+	res := execStr(t, `#for(i in range(10, 0, -3)):#(i) #!for`, nil)
+	if res != "10 7 4 1 " {
+		t.Errorf("Expected %q, got %q", "10 7 4 1 ", res)
+	}
+}
+
+func TestForString(t *testing.T) {
+	res := execStr(t, `#for(i, c in s):#(i)#(c) #!for`, map[string]any{"s": "aé😀b"})
+	const expected = "0a 1é 3😀 7b "
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForChan(t *testing.T) {
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	res := execStr(t, `#for(v in ch):#(v)#(loop.Length)#(loop.Last)#!for`, map[string]any{"ch": (<-chan int)(ch)})
+	const expected = "1-1false2-1false3-1false"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForChanBreak(t *testing.T) {
+	ch := make(chan int, 5)
+	for i := range 5 {
+		ch <- i
+	}
+	close(ch)
+
+	res := execStr(t, `#for(x in ch):#(x)#break#!for`, map[string]any{"ch": ch})
+	if res != "0" {
+		t.Errorf("Expected %q, got %q", "0", res)
+	}
+
+	if len(ch) != 4 {
+		t.Errorf("Expected 4 items to remain in the channel, got %d", len(ch))
+	}
+}
+
+func TestForSeqBreak(t *testing.T) {
+	produced := 0
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 5; i++ {
+			produced++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	res := execStr(t, `#for(x in seq):#(x)#break#!for`, map[string]any{"seq": seq})
+	if res != "0" {
+		t.Errorf("Expected %q, got %q", "0", res)
+	}
+
+	if produced != 1 {
+		t.Errorf("Expected 1 item to be produced, got %d", produced)
+	}
+}
+
+func TestForSeq(t *testing.T) {
+	seq := func(yield func(string) bool) {
+		for _, s := range []string{"a", "b", "c"} {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+
+	seq2 := func(yield func(string, int) bool) {
+		for i, s := range []string{"x", "y"} {
+			if !yield(s, i) {
+				return
+			}
+		}
+	}
+
+	res := execStr(t, `#for(i, v in seq):#break(i == 2)#(i)#(v)#!for #for(i, k, v in seq2):#(i)#(k)#(v)#!for`, map[string]any{"seq": seq, "seq2": seq2})
+	const expected = "0a1b 0x01y1"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForUnsupported(t *testing.T) {
+	tmpl, err := New().ParseString("test", `#for(v in x):#(v)#!for`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.WithVarMap(map[string]any{"x": struct{}{}}).Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "cannot iterate over value of type struct {}") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestForDestructure(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+
+	vars := map[string]any{
+		"pairs":   [][]any{{"a", 1}, {"b", 2}},
+		"people":  []person{{"Alice", 30}, {"Bob", 25}},
+		"records": []map[string]any{{"name": "Carol", "age": 40}},
+	}
+
+	const tmplStr = `#for(i, [k, v] in pairs):#(i)#(k)#(v) #!for#for([name, Age] in people):#(name)=#(Age) #!for#for([name, age] in records):#(name)=#(age)#!for`
+
+	res := execStr(t, tmplStr, vars)
+	const expected = "0a1 1b2 Alice=30 Bob=25 Carol=40"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForDestructureParens(t *testing.T) {
+	type person struct {
+		Name string
+		Age  int
+	}
+
+	vars := map[string]any{
+		"pairs":  [][]any{{"a", 1}, {"b", 2}},
+		"people": []person{{"Alice", 30}, {"Bob", 25}},
+		"m":      map[string][]int{"x": {1, 2}},
+	}
+
+	const tmplStr = `#for((name, age) in people):#(name)=#(age) #!for#for(i, ( k , v ) in pairs):#(i)#(k)#(v) #!for#for(key, (a, b) in m):#(key)#(a)#(b)#!for`
+
+	res := execStr(t, tmplStr, vars)
+	const expected = "Alice=30 Bob=25 0a1 1b2 x12"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestForDestructureErrors(t *testing.T) {
+	type person struct {
+		Name string
+	}
+
+	testCases := []struct {
+		name     string
+		tmpl     string
+		vars     map[string]any
+		expected string
+	}{
+		{"length", `#for([a, b] in s):#!for`, map[string]any{"s": [][]int{{1, 2, 3}}}, "test: line 1, col 6: cannot destructure 3 values into 2 variables"},
+		{"field", `#for([name, age] in s):#!for`, map[string]any{"s": []person{{"Alice"}}}, "no such field: age"},
+		{"key", `#for([a] in s):#!for`, map[string]any{"s": []map[string]int{{"b": 1}}}, `no such key: "a"`},
+		{"type", `#for([a] in s):#!for`, map[string]any{"s": []int{1}}, "cannot destructure value of type int"},
+		{"target", `#for([a, 1] in s):#!for`, map[string]any{"s": []int{1}}, "invalid destructuring target"},
+		{"parens", `#for((a, b) in s):#!for`, map[string]any{"s": [][]int{{1, 2, 3}}}, "test: line 1, col 6: cannot destructure 3 values into 2 variables"},
+		{"three", `#for(i, k, v in s):#!for`, map[string]any{"s": []int{1}}, "test: line 1, col 6: only maps and iter.Seq2 functions can use three for loop variables"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl, err := New().ParseString("test", tc.tmpl)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = tmpl.WithVarMap(tc.vars).Execute(io.Discard)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestWhile(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+
+	list := &node{1, &node{2, &node{3, nil}}}
+
+	const tmplStr = `#(n = list)#while(n != nil):#(n.Value)#(n = n.Next)#!while`
+
+	res := execStr(t, tmplStr, map[string]any{"list": list})
+	if res != "123" {
+		t.Errorf("Expected %q, got %q", "123", res)
+	}
+}
+
+func TestWhileBreakContinue(t *testing.T) {
+	const tmplStr = `#(i = 0)#while(true):#(i = i + 1)#break(i > 5)#if(i == 2):#continue#!if#(i)#!while`
+
+	res := execStr(t, tmplStr, nil)
+	if res != "1345" {
+		t.Errorf("Expected %q, got %q", "1345", res)
+	}
+}
+
+func TestWhileMaxIterations(t *testing.T) {
+	tmpl, err := New().WithMaxWhileIterations(3).ParseString("test", `#(i = 0)#while(true):#(i = i + 1)#!while`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = tmpl.Execute(io.Discard)
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "exceeded maximum amount of iterations (3)") {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
+
+func TestSwitch(t *testing.T) {
+	const tmplStr = `#switch(status):
+#case("active", "enabled"):on
+#case("off"):off
+#default:unknown
+#!switch`
+
+	testCases := []struct {
+		status   any
+		expected string
+	}{
+		{"active", "on\n"},
+		{"enabled", "on\n"},
+		{"off", "off\n"},
+		{"disabled", "unknown\n"},
+	}
+
+	for _, tc := range testCases {
+		res := execStr(t, tmplStr, map[string]any{"status": tc.status})
+		if res != tc.expected {
+			t.Errorf("%v: expected %q, got %q", tc.status, tc.expected, res)
+		}
+	}
+}
+
+func TestSwitchConversion(t *testing.T) {
+	res := execStr(t, `#switch(n):#case(1):one#case(2):two#!switch`, map[string]any{"n": uint8(2)})
+	if res != "two" {
+		t.Errorf("Expected %q, got %q", "two", res)
+	}
+}
+
+func TestSwitchNested(t *testing.T) {
+	const tmplStr = `#switch(a):#case(1):#switch(b):#case(2):a1b2#default:a1#!switch#default:none#!switch`
+
+	res := execStr(t, tmplStr, map[string]any{"a": 1, "b": 3})
+	if res != "a1" {
+		t.Errorf("Expected %q, got %q", "a1", res)
+	}
+
+	res = execStr(t, tmplStr, map[string]any{"a": 2, "b": 2})
+	if res != "none" {
+		t.Errorf("Expected %q, got %q", "none", res)
+	}
+}
+
+func TestSwitchEvaluatesOnce(t *testing.T) {
+	calls := 0
+	fn := func() int {
+		calls++
+		return 3
+	}
+
+	res := execStr(t, `#switch(fn()):#case(1):one#case(2):two#case(3):three#!switch`, map[string]any{"fn": fn})
+	if res != "three" {
+		t.Errorf("Expected %q, got %q", "three", res)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected subject to be evaluated once, got %d calls", calls)
+	}
+}
+
+func TestSet(t *testing.T) {
+	const tmplStr = `#set(total = 0)#for(n in s):#set(total = total + n)#!for#(total)`
+
+	res := execStr(t, tmplStr, map[string]any{"s": []int{1, 2, 3}})
+	if res != "6" {
+		t.Errorf("Expected %q, got %q", "6", res)
+	}
+}
+
+func TestSetDefinesInRoot(t *testing.T) {
+	const tmplStr = `#if(true):#set(x = "set")#!if#(x)`
+
+	res := execStr(t, tmplStr, nil)
+	if res != "set" {
+		t.Errorf("Expected %q, got %q", "set", res)
+	}
+}
+
+func TestAssignmentBlockScoped(t *testing.T) {
+	const tmplStr = `#(x = 1)#if(true):#(x = 2)#(x)#!if#(x)`
+
+	res := execStr(t, tmplStr, nil)
+	if res != "21" {
+		t.Errorf("Expected %q, got %q", "21", res)
+	}
+}
+
+func TestLet(t *testing.T) {
+	const tmplStr = `#(x = 1)#let(x = 2, y = x + 1):#(x)#(y)#set(x = 5)#(x)#!let#(x)#if(true):#let(x = 3)#(x)#!if#(x)`
+
+	res := execStr(t, tmplStr, nil)
+	const expected = "235131"
+	if res != expected {
+		t.Errorf("Expected %q, got %q", expected, res)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	tmpl, err := New().WithEscapeHTML(true).ParseString("test", `#capture("title"):#(page) | <b>Site</b>#!capture<title>#(title)</title><h1>#(title)</h1>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.WithVarMap(map[string]any{"page": "A & B"}).Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = "<title>A &amp; B | <b>Site</b></title><h1>A &amp; B | <b>Site</b></h1>"
+	if sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+}
+
+func TestCaptureScope(t *testing.T) {
+	const tmplStr = `#if(true):#capture("x"):inner#!capture#(x)#!if #(x | "none")`
+
+	res := execStr(t, tmplStr, nil)
+	if res != "inner none" {
+		t.Errorf("Expected %q, got %q", "inner none", res)
+	}
+}
+
+func TestForMapRandom(t *testing.T) {
+	tmpl, err := New().WithMapOrder(MapOrderRandom).ParseString("test", `#for(v in m):#(v)#!for`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sb := &strings.Builder{}
+	err = tmpl.WithVarMap(map[string]any{"m": map[string]int{"a": 1, "b": 2, "c": 3}}).Execute(sb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sb.String()) != 3 || !strings.Contains(sb.String(), "1") || !strings.Contains(sb.String(), "2") || !strings.Contains(sb.String(), "3") {
+		t.Errorf("Unexpected output: %q", sb.String())
+	}
+}
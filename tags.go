@@ -3,6 +3,9 @@ package salix
 import (
 	"bytes"
 	"io"
+	"maps"
+	"reflect"
+	"slices"
 
 	"go.elara.ws/salix/ast"
 )
@@ -13,10 +16,57 @@ type Tag interface {
 }
 
 var globalTags = map[string]Tag{
-	"if":      ifTag{},
-	"for":     forTag{},
-	"include": includeTag{},
-	"macro":   macroTag{},
+	"if":       ifTag{},
+	"for":      forTag{},
+	"include":  includeTag{},
+	"macro":    macroTag{},
+	"break":    breakTag{},
+	"continue": continueTag{},
+	"while":    whileTag{},
+	"switch":   switchTag{},
+	"set":      setTag{},
+	"let":      letTag{},
+	"capture":  captureTag{},
+	"extends":  extendsTag{},
+	"block":    blockTag{},
+}
+
+// markerTags contains the names of tags that divide the blocks of other tags,
+// such as #else. They have a colon but no end tag, so they don't start a new block.
+var markerTags = map[string]bool{
+	"elif":    true,
+	"else":    true,
+	"empty":   true,
+	"case":    true,
+	"default": true,
+}
+
+// depthChange returns the amount by which node changes the nesting depth
+// of a block. Tags with bodies increase it, and end tags decrease it.
+func depthChange(node ast.Node) int {
+	switch node := node.(type) {
+	case ast.Tag:
+		if node.HasBody && !markerTags[node.Name.Value] {
+			return 1
+		}
+	case ast.EndTag:
+		return -1
+	}
+	return 0
+}
+
+// findMarkers returns the indices of the marker tags with the given names
+// in block, ignoring any that belong to tags nested within it.
+func findMarkers(block []ast.Node, names ...string) []int {
+	var out []int
+	depth := 0
+	for i, node := range block {
+		if tag, ok := node.(ast.Tag); ok && depth == 0 && slices.Contains(names, tag.Name.Value) {
+			out = append(out, i)
+		}
+		depth += depthChange(node)
+	}
+	return out
 }
 
 // TagContext is passed to Tag implementations to allow them to control the interpreter
@@ -24,19 +74,25 @@ type TagContext struct {
 	Tag   ast.Tag
 	w     io.Writer
 	t     *Template
-	local map[string]any
+	local *scope
 }
 
 // Execute runs the interpreter on the given AST nodes, with the given local variables.
 func (tc *TagContext) Execute(nodes []ast.Node, local map[string]any) error {
-	return tc.t.execute(tc.w, nodes, mergeMap(tc.local, local))
+	if err := tc.checkShadow(local); err != nil {
+		return err
+	}
+	return tc.t.execute(tc.w, nodes, newScope(tc.local, maps.Clone(local)))
 }
 
 // ExecuteToMemory runs the interpreter on the given AST nodes, with the given local variables, and
 // returns the resulting bytes rather than writing them out.
 func (tc *TagContext) ExecuteToMemory(nodes []ast.Node, local map[string]any) ([]byte, error) {
+	if err := tc.checkShadow(local); err != nil {
+		return nil, err
+	}
 	buf := &bytes.Buffer{}
-	err := tc.t.execute(buf, nodes, mergeMap(tc.local, local))
+	err := tc.t.execute(buf, nodes, newScope(tc.local, maps.Clone(local)))
 	if err != nil {
 		return nil, err
 	}
@@ -45,7 +101,17 @@ func (tc *TagContext) ExecuteToMemory(nodes []ast.Node, local map[string]any) ([
 
 // GetValue evaluates the given AST node using the given local variables.
 func (tc *TagContext) GetValue(node ast.Node, local map[string]any) (any, error) {
-	return tc.t.getValue(node, mergeMap(tc.local, local))
+	if local == nil {
+		return tc.t.getValue(node, tc.local)
+	}
+	return tc.t.getValue(node, newScope(tc.local, maps.Clone(local)))
+}
+
+// IsTrue returns the truth value of v, using the same rules as
+// if tags and logical operators. If v can't be used as a condition,
+// ok is false.
+func (tc *TagContext) IsTrue(v any) (cond, ok bool) {
+	return tc.t.isTrue(reflect.ValueOf(v))
 }
 
 // PosError returns an error with the file position prepended. This should be used
@@ -66,17 +132,13 @@ func (tc *TagContext) Write(b []byte) (int, error) {
 	return tc.w.Write(b)
 }
 
-func mergeMap(a, b map[string]any) map[string]any {
-	out := map[string]any{}
-	if a != nil {
-		for k, v := range a {
-			out[k] = v
+// checkShadow returns an error if strict mode is enabled and any
+// of the given local variables shadow a global function.
+func (tc *TagContext) checkShadow(local map[string]any) error {
+	for name := range local {
+		if err := tc.t.checkShadow(tc.Tag, name); err != nil {
+			return err
 		}
 	}
-	if b != nil {
-		for k, v := range b {
-			out[k] = v
-		}
-	}
-	return out
+	return nil
 }
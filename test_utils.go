@@ -11,15 +11,11 @@ func testTmpl(t *testing.T) Template {
 	t.Helper()
 
 	return Template{
-		ns: &Namespace{
-			tmpls: map[string]Template{},
-			tags:  map[string]Tag{},
-			vars:  map[string]any{},
-		},
+		ns:     New(),
 		name:   t.Name(),
 		tags:   map[string]Tag{},
 		vars:   map[string]any{},
-		macros: map[string][]ast.Node{},
+		macros: map[string]macro{},
 	}
 }
 
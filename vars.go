@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -24,6 +25,8 @@ var globalVars = map[string]any{
 	"replace":    strings.Replace,
 	"replaceAll": strings.ReplaceAll,
 	"sprintf":    fmt.Sprintf,
+	"sort":       tmplSort,
+	"range":      tmplRange,
 }
 
 func tmplLen(v any) (int, error) {
@@ -36,7 +39,69 @@ func tmplLen(v any) (int, error) {
 	}
 }
 
+// tmplRange returns a slice of integers from start to end (exclusive), incremented
+// by step. It accepts one argument (end), two arguments (start, end), or
+// three arguments (start, end, step).
+func tmplRange(args ...int) ([]int, error) {
+	start, end, step := 0, 0, 1
+	switch len(args) {
+	case 1:
+		end = args[0]
+	case 2:
+		start, end = args[0], args[1]
+	case 3:
+		start, end, step = args[0], args[1], args[2]
+	default:
+		return nil, fmt.Errorf("range expects one to three arguments, got %d", len(args))
+	}
+
+	if step == 0 {
+		return nil, fmt.Errorf("range step cannot be zero")
+	}
+
+	var out []int
+	for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+		out = append(out, i)
+	}
+	return out, nil
+}
+
 func tmplJSON(v any) (HTML, error) {
 	data, err := json.Marshal(v)
 	return HTML(data), err
 }
+
+func tmplSort(v any) ([]any, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot sort %T", v)
+	}
+
+	out := make([]reflect.Value, val.Len())
+	for i := range out {
+		out[i] = val.Index(i)
+		// Unwrap interface values so that the underlying
+		// values are compared rather than the interfaces
+		if out[i].Kind() == reflect.Interface {
+			out[i] = out[i].Elem()
+		}
+	}
+
+	var err error
+	slices.SortStableFunc(out, func(a, b reflect.Value) int {
+		c, cmpErr := compareValues(a, b)
+		if cmpErr != nil && err == nil {
+			err = cmpErr
+		}
+		return c
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, len(out))
+	for i, item := range out {
+		res[i] = item.Interface()
+	}
+	return res, nil
+}
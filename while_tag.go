@@ -0,0 +1,48 @@
+package salix
+
+import (
+	"reflect"
+
+	"go.elara.ws/salix/ast"
+)
+
+// DefaultMaxWhileIterations is the default maximum amount of
+// iterations a #while tag can execute before returning an error.
+const DefaultMaxWhileIterations = 10000
+
+// whileTag represents a #while tag within a Salix template
+type whileTag struct{}
+
+func (wt whileTag) Run(tc *TagContext, block, args []ast.Node) error {
+	if len(args) != 1 {
+		return tc.PosError(tc.Tag, "expected one argument, got %d", len(args))
+	}
+
+	maxIterations := tc.t.ns.MaxWhileIterations
+
+	// The block is executed directly using the same scope every time rather than
+	// through tc.Execute, so that assignments persist across iterations.
+	local := newScope(tc.local, nil)
+	for i := 0; ; i++ {
+		val, err := tc.t.getValue(args[0], local)
+		if err != nil {
+			return err
+		}
+
+		cond, ok := tc.t.isTrue(reflect.ValueOf(val))
+		if !ok {
+			return tc.PosError(args[0], "expected boolean argument, got %T", val)
+		} else if !cond {
+			return nil
+		}
+
+		if maxIterations > 0 && i >= maxIterations {
+			return tc.PosError(tc.Tag, "exceeded maximum amount of iterations (%d)", maxIterations)
+		}
+
+		stop, err := handleLoopSignal(tc.t.execute(tc.w, block, local))
+		if stop {
+			return err
+		}
+	}
+}